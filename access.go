@@ -0,0 +1,81 @@
+package dyad
+
+import "reflect"
+
+// Get returns the value at the path p within v.
+//
+// p must be a path within a value of type T, such as one returned by
+// ParsePath(). Pointers are dereferenced as necessary, as they do not appear
+// within paths. The returned value is not cloned, and so may share data with
+// v.
+//
+// It navigates through unexported fields according to the same strategy used
+// by Clone(). It returns an error if p does not exist within v, or if it
+// refers to a value within an unexported field that is ignored.
+func Get[T any](v T, p Path, options ...Option) (any, error) {
+	root := reflect.ValueOf(&v).Elem()
+	ctx := newCloneContext(options).WithRoot(root.Type())
+
+	elems, ok := relativeElems(p, root.Type())
+	if !ok {
+		return nil, ctx.Error("cannot navigate to %s", p)
+	}
+
+	var (
+		value any
+		found bool
+	)
+
+	err := navigate(
+		ctx,
+		root,
+		elems,
+		func(ctx cloneContext, v reflect.Value) error {
+			value = v.Interface()
+			found = true
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		return value, nil
+	}
+
+	ctx.path = p
+	return nil, ctx.Error("value is within an ignored unexported field")
+}
+
+// Set replaces the value at the path p within the value pointed to by v with a
+// clone of x.
+//
+// p must be a path within a value of type T, such as one returned by
+// ParsePath(). Pointers are dereferenced as necessary, as they do not appear
+// within paths. x must be assignable to the type of the value at p, or nil to
+// set it to its zero-value.
+//
+// It navigates through unexported fields according to the same strategy used
+// by Clone(). Values within unexported fields are left unchanged when using
+// the IgnoreUnexportedFields strategy. It returns an error if p does not exist
+// within v, including if it refers to a map element that is not present.
+func Set[T any](v *T, p Path, x any, options ...Option) error {
+	root := reflect.ValueOf(v).Elem()
+	ctx := newCloneContext(options).WithRoot(root.Type())
+
+	elems, ok := relativeElems(p, root.Type())
+	if !ok {
+		return ctx.Error("cannot navigate to %s", p)
+	}
+
+	return update(
+		ctx,
+		root,
+		elems,
+		func(ctx cloneContext, v reflect.Value) error {
+			return setClone(ctx, v, x)
+		},
+	)
+}
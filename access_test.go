@@ -0,0 +1,241 @@
+package dyad_test
+
+import (
+	"sync"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Get()", func() {
+	type Inner struct {
+		Value int
+	}
+
+	type Value struct {
+		Ptr       *Inner
+		Slice     []Inner
+		Map       map[int]Inner
+		Interface any
+		private   Inner
+	}
+
+	var src Value
+
+	BeforeEach(func() {
+		src = Value{
+			Ptr:       &Inner{1},
+			Slice:     []Inner{{2}, {3}},
+			Map:       map[int]Inner{10: {4}},
+			Interface: Inner{5},
+			private:   Inner{6},
+		}
+	})
+
+	DescribeTable(
+		"it returns the value at the path",
+		func(s string, expect any) {
+			p, err := dyad.ParsePath[Value](s)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			x, err := dyad.Get(
+				src,
+				p,
+				dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(x).To(Equal(expect))
+		},
+		Entry("pointer", `dyad_test.Value.Ptr.Value`, 1),
+		Entry("slice element", `dyad_test.Value.Slice[1].Value`, 3),
+		Entry("map element", `dyad_test.Value.Map[10].Value`, 4),
+		Entry("interface", `dyad_test.Value.Interface(dyad_test.Inner).Value`, 5),
+		Entry("unexported field", `dyad_test.Value.private.Value`, 6),
+	)
+
+	DescribeTable(
+		"it returns an error if the path does not exist",
+		func(s, expect string) {
+			p, err := dyad.ParsePath[Value](s)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = dyad.Get(src, p)
+			Expect(err).To(MatchError(expect))
+		},
+		Entry(
+			"unknown field",
+			`dyad_test.Value.Unknown`,
+			`dyad_test.Value: dyad_test.Value has no field named Unknown`,
+		),
+		Entry(
+			"index out of range",
+			`dyad_test.Value.Slice[2]`,
+			`dyad_test.Value.Slice: index 2 is out of range`,
+		),
+		Entry(
+			"missing key",
+			`dyad_test.Value.Map[20]`,
+			`dyad_test.Value.Map: map does not contain the key 20`,
+		),
+		Entry(
+			"wrong dynamic type",
+			`dyad_test.Value.Interface(int)`,
+			`dyad_test.Value.Interface: interface contains dyad_test.Inner, not int`,
+		),
+		Entry(
+			"unexported field",
+			`dyad_test.Value.private`,
+			`dyad_test.Value: struct cannot be cloned due to unexported field (dyad_test.Value.private), try the dyad.WithUnexportedFieldStrategy() option`,
+		),
+	)
+
+	It("returns an error if the path is within an ignored unexported field", func() {
+		p, err := dyad.ParsePath[Value](`dyad_test.Value.private.Value`)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = dyad.Get(
+			src,
+			p,
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)
+		Expect(err).To(MatchError(
+			`dyad_test.Value.private.Value: value is within an ignored unexported field`,
+		))
+	})
+
+	It("does not modify the maps within the value", func() {
+		type Elem struct {
+			private int
+		}
+
+		type Value struct {
+			Map map[string]Elem
+		}
+
+		v := Value{Map: map[string]Elem{"<key>": {}}}
+
+		p, err := dyad.ParsePath[Value](`dyad_test.Value.Map["<key>"].private`)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// Any write to the map conflicts with the concurrent reads, which is
+		// detected by the race detector.
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+
+				for j := 0; j < 100; j++ {
+					_, _ = dyad.Get(
+						v,
+						p,
+						dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+					)
+					_ = v.Map["<key>"]
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	It("returns an error if the path is within a different type", func() {
+		p, err := dyad.ParsePath[Inner](`dyad_test.Inner.Value`)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = dyad.Get(src, p)
+		Expect(err).To(MatchError(
+			`dyad_test.Value: cannot navigate to dyad_test.Inner.Value`,
+		))
+	})
+})
+
+var _ = Describe("func Set()", func() {
+	type Inner struct {
+		Value int
+	}
+
+	type Value struct {
+		Ptr       *Inner
+		Slice     []Inner
+		Map       map[int]Inner
+		Interface any
+		private   Inner
+	}
+
+	var dst Value
+
+	BeforeEach(func() {
+		dst = Value{
+			Ptr:       &Inner{1},
+			Slice:     []Inner{{2}, {3}},
+			Map:       map[int]Inner{10: {4}},
+			Interface: Inner{5},
+			private:   Inner{6},
+		}
+	})
+
+	set := func(s string, x any, options ...dyad.Option) error {
+		p, err := dyad.ParsePath[Value](s)
+		Expect(err).ShouldNot(HaveOccurred())
+		return dyad.Set(&dst, p, x, options...)
+	}
+
+	It("replaces the value at the path", func() {
+		options := []dyad.Option{
+			dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+		}
+
+		Expect(set(`dyad_test.Value.Ptr.Value`, 10, options...)).To(Succeed())
+		Expect(set(`dyad_test.Value.Slice[1].Value`, 30, options...)).To(Succeed())
+		Expect(set(`dyad_test.Value.Map[10].Value`, 40, options...)).To(Succeed())
+		Expect(set(`dyad_test.Value.Interface(dyad_test.Inner).Value`, 50, options...)).To(Succeed())
+		Expect(set(`dyad_test.Value.private.Value`, 60, options...)).To(Succeed())
+
+		Expect(dst).To(Equal(Value{
+			Ptr:       &Inner{10},
+			Slice:     []Inner{{2}, {30}},
+			Map:       map[int]Inner{10: {40}},
+			Interface: Inner{50},
+			private:   Inner{60},
+		}))
+	})
+
+	It("stores a clone of the value", func() {
+		x := &Inner{10}
+		Expect(set(`dyad_test.Value.Ptr`, x)).To(Succeed())
+
+		Expect(dst.Ptr).To(Equal(x))
+		Expect(dst.Ptr).ToNot(BeIdenticalTo(x))
+	})
+
+	It("sets the value to its zero-value if x is nil", func() {
+		Expect(set(`dyad_test.Value.Ptr`, nil)).To(Succeed())
+		Expect(dst.Ptr).To(BeNil())
+	})
+
+	It("leaves ignored unexported fields unchanged", func() {
+		Expect(set(
+			`dyad_test.Value.private.Value`,
+			60,
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)).To(Succeed())
+
+		Expect(dst.private).To(Equal(Inner{6}))
+	})
+
+	It("returns an error if the value is not assignable", func() {
+		err := set(`dyad_test.Value.Ptr.Value`, "<string>")
+		Expect(err).To(MatchError(
+			`dyad_test.Value.Ptr.Value: cannot use value of type string as int`,
+		))
+	})
+
+	It("returns an error if the map does not contain the key", func() {
+		err := set(`dyad_test.Value.Map[20]`, Inner{})
+		Expect(err).To(MatchError(
+			`dyad_test.Value.Map: map does not contain the key 20`,
+		))
+	})
+})
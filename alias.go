@@ -0,0 +1,87 @@
+package dyad
+
+import (
+	"sort"
+)
+
+// FindAliases returns groups of paths within v that refer to the same memory.
+//
+// Each group contains the paths of the pointers, maps, slices and channels
+// within v that refer to some common memory, such as two pointers to the same
+// value, or two slices with overlapping backing arrays. Map keys are included,
+// and are reported at the path of the corresponding map element. Clone() does
+// not preserve these aliases, so the values at these paths are independent
+// within a clone.
+//
+// The paths within each group are sorted, and the groups are sorted by their
+// first path. It returns an empty slice if v does not contain any aliases.
+//
+// The value is traversed in the same way as Walk(). Unexported fields and
+// channels are included by default, as though the CloneUnexportedFields and
+// ShareChannels strategies were in use. Otherwise, it panics under the same
+// circumstances as Clone().
+func FindAliases[T any](v T, options ...Option) [][]Path {
+	options = append(
+		[]Option{
+			WithUnexportedFieldStrategy(CloneUnexportedFields),
+			WithChannelStrategy(ShareChannels),
+		},
+		options...,
+	)
+
+	regions, err := collectRegions(v, 0, options)
+	if err != nil {
+		panic(err)
+	}
+
+	var aliases [][]Path
+
+	for _, group := range regionGroups(regions) {
+		if len(group) < 2 {
+			continue
+		}
+
+		paths := make([]Path, len(group))
+		for i, r := range group {
+			paths[i] = r.path
+		}
+
+		sort.Slice(paths, func(i, j int) bool {
+			return paths[i].String() < paths[j].String()
+		})
+
+		aliases = append(aliases, paths)
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i][0].String() < aliases[j][0].String()
+	})
+
+	return aliases
+}
+
+// regionGroups partitions regions into groups of regions that overlap, either
+// directly or via other regions in the same group.
+//
+// The regions are sorted in place by their starting address.
+func regionGroups(regions []memoryRegion) [][]memoryRegion {
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].start < regions[j].start
+	})
+
+	var (
+		groups [][]memoryRegion
+		end    uintptr
+	)
+
+	for i, r := range regions {
+		if i == 0 || r.start >= end {
+			groups = append(groups, nil)
+		}
+
+		end = max(end, r.end)
+		groups[len(groups)-1] = append(groups[len(groups)-1], r)
+	}
+
+	return groups
+}
@@ -0,0 +1,118 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func FindAliases()", func() {
+	type Value struct {
+		A, B    *int
+		Slice   []int
+		Window  []int
+		Maps    []map[string]int
+		Element *int
+		private *int
+	}
+
+	render := func(aliases [][]dyad.Path) [][]string {
+		var groups [][]string
+		for _, g := range aliases {
+			var paths []string
+			for _, p := range g {
+				paths = append(paths, p.String())
+			}
+			groups = append(groups, paths)
+		}
+		return groups
+	}
+
+	It("returns an empty slice if the value does not contain any aliases", func() {
+		n, m := 1, 2
+		v := Value{
+			A:     &n,
+			B:     &m,
+			Slice: []int{1, 2, 3},
+		}
+
+		Expect(dyad.FindAliases(v)).To(BeEmpty())
+	})
+
+	It("returns groups of paths that refer to the same memory", func() {
+		n, m := 1, 2
+		shared := map[string]int{}
+
+		v := Value{
+			A:       &n,
+			B:       &n,
+			Slice:   []int{1, 2, 3, 4},
+			Maps:    []map[string]int{shared, shared},
+			private: &m,
+		}
+		v.Window = v.Slice[1:2]
+		v.Element = &v.Slice[3]
+
+		Expect(render(dyad.FindAliases(v))).To(ConsistOf(
+			[]string{
+				"dyad_test.Value.A",
+				"dyad_test.Value.B",
+			},
+			[]string{
+				"dyad_test.Value.Element",
+				"dyad_test.Value.Slice",
+				"dyad_test.Value.Window",
+			},
+			[]string{
+				"dyad_test.Value.Maps[0]",
+				"dyad_test.Value.Maps[1]",
+			},
+		))
+	})
+
+	It("reports aliases within map keys", func() {
+		type Maps struct {
+			A, B map[*int]bool
+		}
+
+		n := 1
+		v := Maps{
+			A: map[*int]bool{&n: true},
+			B: map[*int]bool{&n: false},
+		}
+
+		aliases := render(dyad.FindAliases(v))
+
+		Expect(aliases).To(HaveLen(1))
+		Expect(aliases[0]).To(HaveLen(2))
+		Expect(aliases[0][0]).To(HavePrefix("dyad_test.Maps.A["))
+		Expect(aliases[0][1]).To(HavePrefix("dyad_test.Maps.B["))
+	})
+
+	It("reports cyclic references", func() {
+		type Node struct {
+			Next *Node
+		}
+
+		n := &Node{}
+		n.Next = n
+
+		Expect(render(dyad.FindAliases(n))).To(Equal([][]string{
+			{
+				"*dyad_test.Node",
+				"*dyad_test.Node.Next",
+			},
+		}))
+	})
+
+	It("panics if the value cannot be traversed", func() {
+		Expect(func() {
+			dyad.FindAliases(
+				make(chan int),
+				dyad.WithChannelStrategy(dyad.PanicOnChannel),
+			)
+		}).To(PanicWith(MatchError(
+			"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		)))
+	})
+})
@@ -0,0 +1,191 @@
+package dyad
+
+import "reflect"
+
+// Apply applies a set of differences, such as those returned by Diff(), to the
+// value pointed to by dst.
+//
+// The differences are applied in order. Each one replaces, adds or removes the
+// value at its path within dst, according to its kind. The values within the
+// differences are cloned before they are stored in dst, such that dst never
+// shares data with the patch.
+//
+// It navigates through unexported fields according to the same strategy used
+// by Clone(). Differences within unexported fields are skipped when using the
+// IgnoreUnexportedFields strategy.
+//
+// It returns an error if a difference cannot be applied, such as when its
+// path does not exist within dst. Differences that precede the failing
+// difference remain applied.
+func Apply[T any](dst *T, patch []Difference, options ...Option) error {
+	root := reflect.ValueOf(dst).Elem()
+	ctx := newCloneContext(options).WithRoot(root.Type())
+
+	for _, d := range patch {
+		if err := applyDifference(ctx, root, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyDifference(
+	ctx cloneContext,
+	root reflect.Value,
+	d Difference,
+) error {
+	elems, ok := relativeElems(d.Path, root.Type())
+	if !ok {
+		return ctx.Error("cannot apply difference at %s", d.Path)
+	}
+
+	if d.Kind == ValueModified {
+		return update(
+			ctx,
+			root,
+			elems,
+			func(ctx cloneContext, v reflect.Value) error {
+				return setClone(ctx, v, d.B)
+			},
+		)
+	}
+
+	if len(elems) == 0 {
+		return ctx.Error("cannot add or remove the root value")
+	}
+
+	last := elems[len(elems)-1]
+
+	return update(
+		ctx,
+		root,
+		elems[:len(elems)-1],
+		func(ctx cloneContext, v reflect.Value) error {
+			v, err := deref(ctx, v)
+			if err != nil {
+				return err
+			}
+
+			if d.Kind == ValueAdded {
+				return addElement(ctx, v, last, d.B)
+			}
+
+			return removeElement(ctx, v, last)
+		},
+	)
+}
+
+// setClone sets v to a clone of x.
+//
+// If x is not assignable to v, but is assignable to the value that v points
+// to, the value that v points to is set to a clone of x, allocating it first
+// if v is nil. This occurs because a pointer and the value it points to share
+// the same path.
+func setClone(ctx cloneContext, v reflect.Value, x any) error {
+	if x == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	src := reflect.ValueOf(x)
+	if !src.Type().AssignableTo(v.Type()) {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			return setClone(ctx, v.Elem(), x)
+		}
+
+		return ctx.Error("cannot use value of type %s as %s", src.Type(), v.Type())
+	}
+
+	dst := reflect.New(src.Type()).Elem()
+	if err := cloneInto(ctx, src, dst); err != nil {
+		return err
+	}
+
+	v.Set(dst)
+
+	return nil
+}
+
+// addElement adds a clone of x to the slice or map v at the location
+// identified by e.
+func addElement(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+	x any,
+) error {
+	switch {
+	case v.Kind() == reflect.Slice && e.kind == indexElem:
+		if e.index != v.Len() {
+			return ctx.Error("cannot add element at index %d of slice with length %d", e.index, v.Len())
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setClone(ctx.WithIndex(e.index), elem, x); err != nil {
+			return err
+		}
+
+		v.Set(reflect.Append(v, elem))
+
+	case v.Kind() == reflect.Map && e.kind == keyElem:
+		key, err := mapKey(ctx, v, e)
+		if err != nil {
+			return err
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setClone(ctx.WithKey(key), elem, x); err != nil {
+			return err
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+
+		v.SetMapIndex(key, elem)
+
+	default:
+		return ctx.Error("cannot add elements to %s", v.Type())
+	}
+
+	return nil
+}
+
+// removeElement removes the element identified by e from the slice or map v.
+func removeElement(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+) error {
+	switch {
+	case v.Kind() == reflect.Slice && e.kind == indexElem:
+		if e.index < 0 || e.index >= v.Len() {
+			return ctx.WithIndex(e.index).Error("index %d is out of range", e.index)
+		}
+
+		v.Set(
+			reflect.AppendSlice(
+				v.Slice(0, e.index),
+				v.Slice(e.index+1, v.Len()),
+			),
+		)
+
+	case v.Kind() == reflect.Map && e.kind == keyElem:
+		key, err := mapKey(ctx, v, e)
+		if err != nil {
+			return err
+		}
+
+		v.SetMapIndex(key, reflect.Value{})
+
+	default:
+		return ctx.Error("cannot remove elements from %s", v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Apply()", func() {
+	type Value struct {
+		Name      string
+		Ptr       *string
+		Tags      []string
+		Attrs     map[string][]int
+		Interface any
+	}
+
+	It("applies the differences between two values", func() {
+		name := "<name>"
+		a := Value{
+			Name:      "<name>",
+			Tags:      []string{"<a>", "<b>", "<c>"},
+			Attrs:     map[string][]int{"one": {1}, "two": {2}},
+			Interface: 1,
+		}
+
+		b := Value{
+			Name:      "<changed>",
+			Ptr:       &name,
+			Tags:      []string{"<a>"},
+			Attrs:     map[string][]int{"two": {2, 22}, "three": {3}},
+			Interface: []string{"<elem>"},
+		}
+
+		dst := dyad.Clone(a)
+		err := dyad.Apply(&dst, dyad.Diff(a, b))
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(b))
+	})
+
+	It("applies differences to the values that pointers refer to", func() {
+		type Pointers struct {
+			Int   *int
+			Slice *[]string
+			Nil   *[]string
+		}
+
+		one, two := 1, 2
+
+		a := Pointers{
+			Int:   &one,
+			Slice: &[]string{"<a>"},
+			Nil:   new([]string),
+		}
+
+		b := Pointers{
+			Int:   &two,
+			Slice: &[]string{"<b>", "<c>"},
+			Nil:   &[]string{"<d>"},
+		}
+
+		dst := dyad.Clone(a)
+		err := dyad.Apply(&dst, dyad.Diff(a, b))
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(b))
+		Expect(*a.Int).To(Equal(1))
+		Expect(*a.Slice).To(Equal([]string{"<a>"}))
+	})
+
+	It("preserves aliases within the destination when applying differences through pointers", func() {
+		type Pair struct {
+			A, B *int
+		}
+
+		one, two := 1, 2
+		a := Pair{A: &one}
+		b := Pair{A: &two}
+
+		n := 1
+		dst := Pair{A: &n, B: &n}
+		err := dyad.Apply(&dst, dyad.Diff(a, b))
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst.A).To(BeIdenticalTo(&n))
+		Expect(*dst.B).To(Equal(2))
+	})
+
+	It("resolves map keys that contain pointers by deep equality", func() {
+		k1, k2 := "<k1>", "<k2>"
+		a := map[*string]int{&k1: 1, &k2: 2}
+		b := map[*string]int{&k1: 10, &k2: 2}
+
+		dst := dyad.Clone(a)
+		err := dyad.Apply(&dst, dyad.Diff(a, b))
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dyad.Equal(dst, b)).To(BeTrue())
+		Expect(a[&k1]).To(Equal(1))
+	})
+
+	It("can apply differences onto a value other than the one that was compared", func() {
+		a := Value{Tags: []string{"<a>"}, Attrs: map[string][]int{}}
+		b := Value{Tags: []string{"<a>", "<b>"}, Attrs: map[string][]int{"one": {1}}}
+
+		dst := Value{Name: "<name>", Tags: []string{"<x>"}}
+		err := dyad.Apply(&dst, dyad.Diff(a, b))
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(Value{
+			Name:  "<name>",
+			Tags:  []string{"<x>", "<b>"},
+			Attrs: map[string][]int{"one": {1}},
+		}))
+	})
+
+	It("clones the values that are stored in the destination", func() {
+		a := Value{}
+		b := Value{Tags: []string{"<a>"}}
+		patch := dyad.Diff(a, b)
+
+		err := dyad.Apply(&a, patch)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		a.Tags[0] = "<changed>"
+		Expect(patch[0].B).To(Equal([]string{"<a>"}))
+	})
+
+	It("navigates through unexported fields according to the unexported field strategy", func() {
+		type Inner struct {
+			unexported string
+		}
+
+		type Outer struct {
+			Inner Inner
+		}
+
+		a := Outer{Inner{"<a>"}}
+		b := Outer{Inner{"<b>"}}
+		patch := dyad.Diff(a, b, dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields))
+
+		dst := a
+		err := dyad.Apply(&dst, patch)
+		Expect(err).To(MatchError(
+			"dyad_test.Outer.Inner: struct cannot be cloned due to unexported field (dyad_test.Inner.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+		))
+
+		err = dyad.Apply(&dst, patch, dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(a))
+
+		err = dyad.Apply(&dst, patch, dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(b))
+	})
+
+	It("returns an error if the path does not exist", func() {
+		a := map[string]int{"<key>": 1}
+		b := map[string]int{"<key>": 2}
+
+		dst := map[string]int{}
+		err := dyad.Apply(&dst, dyad.Diff(a, b))
+
+		Expect(err).To(MatchError(
+			`map[string]int["<key>"]: map does not contain this key`,
+		))
+	})
+})
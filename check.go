@@ -0,0 +1,29 @@
+package dyad
+
+import "errors"
+
+// Check returns an error describing every value within type T that can never
+// be cloned using the given options.
+//
+// Unlike Clone(), Check() inspects the type itself rather than any particular
+// value. Problems are therefore reported even if the offending value is rarely
+// populated, making it suitable for use in init() functions and tests.
+//
+// Paths within the error use "[*]" to refer to any element of a slice or map
+// and "[key]" to refer to any key of a map. The contents of interfaces cannot
+// be checked, as their types are only known at runtime.
+//
+// It returns nil if values of type T can always be cloned.
+func Check[T any](options ...Option) error {
+	var errs []error
+
+	Explain[T](options...).walk(
+		func(p *Plan) {
+			if p.err != nil {
+				errs = append(errs, p.err)
+			}
+		},
+	)
+
+	return errors.Join(errs...)
+}
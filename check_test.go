@@ -0,0 +1,87 @@
+package dyad_test
+
+import (
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Check()", func() {
+	It("returns nil if the type can always be cloned", func() {
+		type Type struct {
+			Value     string
+			Ptr       *int
+			Slice     []string
+			Map       map[string]int
+			Interface any
+			Time      time.Time
+		}
+
+		Expect(dyad.Check[Type]()).To(Succeed())
+	})
+
+	It("returns an error if a struct contains unexported fields", func() {
+		type Type struct {
+			unexported string
+		}
+
+		Expect(dyad.Check[Type]()).To(MatchError(
+			"dyad_test.Type: struct cannot be cloned due to unexported field (dyad_test.Type.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+		))
+	})
+
+	It("returns an error if the type contains channels", func() {
+		type Type struct {
+			Channel chan int
+		}
+
+		Expect(dyad.Check[Type]()).To(MatchError(
+			"dyad_test.Type.Channel: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+	})
+
+	It("reports every problem within the type", func() {
+		type Elem struct {
+			unexported string
+		}
+
+		type Type struct {
+			Slice    []Elem
+			Map      map[Elem]*Elem
+			Channels []chan int
+		}
+
+		Expect(dyad.Check[Type]()).To(MatchError(
+			"dyad_test.Type.Slice[*]: struct cannot be cloned due to unexported field (dyad_test.Elem.unexported), try the dyad.WithUnexportedFieldStrategy() option\n" +
+				"dyad_test.Type.Map[key]: struct cannot be cloned due to unexported field (dyad_test.Elem.unexported), try the dyad.WithUnexportedFieldStrategy() option\n" +
+				"dyad_test.Type.Map[*]: struct cannot be cloned due to unexported field (dyad_test.Elem.unexported), try the dyad.WithUnexportedFieldStrategy() option\n" +
+				"dyad_test.Type.Channels[*]: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+	})
+
+	It("honors the clone options", func() {
+		type Type struct {
+			Channel    chan int
+			unexported string
+		}
+
+		Expect(dyad.Check[Type](
+			dyad.WithChannelStrategy(dyad.ShareChannels),
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)).To(Succeed())
+	})
+
+	It("supports recursive types", func() {
+		type Type struct {
+			Next     *Type
+			Children []Type
+			Channel  chan int
+		}
+
+		Expect(dyad.Check[Type]()).To(MatchError(
+			"dyad_test.Type.Channel: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+	})
+})
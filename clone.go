@@ -1,6 +1,7 @@
 package dyad
 
 import (
+	"fmt"
 	"reflect"
 	"time"
 
@@ -18,17 +19,34 @@ func Clone[T any](src T, options ...Option) (dst T) {
 }
 
 func clone[T any](src T, options []Option) (dst T, err error) {
-	var ctx cloneContext
-
-	for _, o := range options {
-		o(&ctx.options)
-	}
+	return cloneWithin(newCloneContext(options), src)
+}
 
+// cloneWithin returns a deep copy of src using the given context.
+func cloneWithin[T any](ctx cloneContext, src T) (dst T, err error) {
 	srcV := reflect.ValueOf(&src).Elem()
 	dstV := reflect.ValueOf(&dst).Elem()
 
+	observers := ctx.options.Observers()
+	if len(observers) != 0 && ctx.options.report == nil {
+		// Observers are told the number of nodes visited, which is only
+		// counted when there is a report.
+		ctx.options.report = &Report{}
+	}
+
+	if r := ctx.options.report; r != nil {
+		*r = Report{}
+
+		defer func(start time.Time) {
+			r.Duration = time.Since(start)
+			notifyCompleted(observers, srcV.Type(), r, err)
+		}(time.Now())
+	}
+
+	notifyStarted(observers, srcV.Type())
+
 	err = cloneInto(
-		ctx.WithPath("%s", srcV.Type()),
+		ctx.WithRoot(srcV.Type()),
 		srcV,
 		dstV,
 	)
@@ -48,8 +66,19 @@ func cloneInto(
 	ctx cloneContext,
 	src, dst reflect.Value,
 ) error {
+	ctx.depth++
+	ctx.Visit(src)
+
+	if t, ok := ctx.options.transforms[src.Type()]; ok {
+		ctx.Trace(TransformApplied, src.Type())
+		dst.Set(t(ctx.path, src))
+		return nil
+	}
+
 	switch src.Type() {
 	case timeType:
+		ctx.Trace(TimeShared, src.Type())
+		ctx.Copied(src.Type())
 		dst.Set(src)
 		return nil
 	}
@@ -61,6 +90,8 @@ func cloneInto(
 		return clonePtrInto(ctx, src, dst)
 	case reflect.Slice:
 		return cloneSliceInto(ctx, src, dst)
+	case reflect.Array:
+		return cloneArrayInto(ctx, src, dst)
 	case reflect.Map:
 		return cloneMapInto(ctx, src, dst)
 	case reflect.Struct:
@@ -68,6 +99,7 @@ func cloneInto(
 	case reflect.Chan:
 		return cloneChannelInto(ctx, src, dst)
 	default:
+		ctx.Copied(src.Type())
 		dst.Set(src)
 		return nil
 	}
@@ -83,9 +115,10 @@ func cloneInterfaceInto(
 
 	srcElem := src.Elem()
 	dstElem := reflect.New(srcElem.Type()).Elem()
+	ctx.Allocated()
 
 	if err := cloneInto(
-		ctx.WithPath("(%s)", srcElem.Type()),
+		ctx.WithType(srcElem.Type()),
 		srcElem,
 		dstElem,
 	); err != nil {
@@ -105,9 +138,16 @@ func clonePtrInto(
 		return nil
 	}
 
+	if existing, ok := ctx.cloneOf(src); ok {
+		dst.Set(existing)
+		return nil
+	}
+
 	srcElem := src.Elem()
 	dstPtr := reflect.New(srcElem.Type())
 	dstElem := dstPtr.Elem()
+	ctx.Allocated()
+	ctx.remember(src, dstPtr)
 
 	if err := cloneInto(ctx, srcElem, dstElem); err != nil {
 		return err
@@ -126,19 +166,47 @@ func cloneSliceInto(
 		return nil
 	}
 
+	if existing, ok := ctx.cloneOf(src); ok {
+		dst.Set(existing)
+		return nil
+	}
+
 	size := src.Len()
 
-	dst.Set(
-		reflect.MakeSlice(
-			src.Type(),
-			size,
-			src.Cap(),
-		),
+	slice := reflect.MakeSlice(
+		src.Type(),
+		size,
+		src.Cap(),
 	)
+	ctx.Allocated()
+	ctx.remember(src, slice)
 
 	for i := 0; i < size; i++ {
 		if err := cloneInto(
-			ctx.WithPath("[%d]", i),
+			ctx.WithIndex(i),
+			src.Index(i),
+			slice.Index(i),
+		); err != nil {
+			return err
+		}
+	}
+
+	dst.Set(slice)
+
+	return nil
+}
+
+// cloneArrayInto clones each element of the array src into dst.
+//
+// Arrays are cloned element-wise, rather than by assignment, so that any
+// pointers, slices or maps within the elements are cloned.
+func cloneArrayInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	for i := 0; i < src.Len(); i++ {
+		if err := cloneInto(
+			ctx.WithIndex(i),
 			src.Index(i),
 			dst.Index(i),
 		); err != nil {
@@ -157,16 +225,22 @@ func cloneMapInto(
 		return nil
 	}
 
+	if existing, ok := ctx.cloneOf(src); ok {
+		dst.Set(existing)
+		return nil
+	}
+
 	mapType := src.Type()
 	keyType := mapType.Key()
 	elemType := mapType.Elem()
 
-	dst.Set(
-		reflect.MakeMap(mapType),
-	)
+	m := reflect.MakeMap(mapType)
+	dst.Set(m)
+	ctx.Allocated()
+	ctx.remember(src, m)
 
 	for _, srcKey := range src.MapKeys() {
-		ctx := ctx.WithPath("[%#v]", srcKey.Interface())
+		ctx := ctx.WithKey(srcKey)
 		srcElem := src.MapIndex(srcKey)
 
 		dstKey := reflect.New(keyType).Elem()
@@ -179,7 +253,7 @@ func cloneMapInto(
 			return err
 		}
 
-		dst.SetMapIndex(dstKey, dstElem)
+		m.SetMapIndex(dstKey, dstElem)
 	}
 
 	return nil
@@ -194,28 +268,30 @@ func cloneStructInto(
 
 	for i := 0; i < size; i++ {
 		field := srcType.Field(i)
-		srcField := src.Field(i)
-		dstField := dst.Field(i)
-
-		// If the field is unexported
-		if field.PkgPath != "" {
-			switch ctx.options.unexportedFieldStrategy {
-			case CloneUnexportedFields:
-				srcField = unsafereflect.MakeMutable(srcField)
-				dstField = unsafereflect.MakeMutable(dstField)
-			case IgnoreUnexportedFields:
-				continue
-			default:
-				return ctx.Error(
-					"struct cannot be cloned due to unexported field (%s.%s), try the dyad.WithUnexportedFieldStrategy() option",
-					srcType,
-					field.Name,
-				)
+
+		srcField, ok, err := fieldValue(ctx, src, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			ctx.WithField(field.Name).Event(UnexportedFieldIgnored, field.Type)
+			continue
+		}
+
+		dstField, _, _ := fieldValue(ctx, dst, i)
+
+		if ctx.options.redact && isRedacted(field) {
+			ctx := ctx.WithField(field.Name)
+			ctx.Event(FieldRedacted, field.Type)
+
+			if mask, ok := ctx.options.masks[field.Type]; ok {
+				dstField.Set(mask(ctx.path, srcField))
 			}
+			continue
 		}
 
 		if err := cloneInto(
-			ctx.WithPath(".%s", field.Name),
+			ctx.WithField(field.Name),
 			srcField,
 			dstField,
 		); err != nil {
@@ -232,11 +308,83 @@ func cloneChannelInto(
 ) error {
 	switch ctx.options.channelStrategy {
 	case ShareChannels:
+		ctx.Event(ChannelShared, src.Type())
+		ctx.Copied(src.Type())
 		dst.Set(src)
 	case IgnoreChannels:
+		ctx.Event(ChannelIgnored, src.Type())
 	default:
-		return ctx.Error("channels cannot be cloned, try the dyad.WithChannelStrategy() option")
+		return channelError(ctx)
 	}
 
 	return nil
 }
+
+// includeField returns true if the field f of the struct type t is included
+// when traversing values of type t, according to the unexported field
+// strategy.
+//
+// It returns an error if f is unexported and the strategy does not permit
+// unexported fields.
+func includeField(
+	ctx cloneContext,
+	t reflect.Type,
+	f reflect.StructField,
+) (bool, error) {
+	if f.IsExported() {
+		return true, nil
+	}
+
+	switch ctx.options.unexportedFieldStrategy {
+	case CloneUnexportedFields:
+		return true, nil
+	case IgnoreUnexportedFields:
+		return false, nil
+	default:
+		return false, unexportedFieldError(ctx, t, f)
+	}
+}
+
+// fieldValue returns the i'th field of the struct v, or false if the field is
+// excluded according to the unexported field strategy.
+//
+// Unexported fields are made mutable, such that they can be read and
+// assigned.
+func fieldValue(
+	ctx cloneContext,
+	v reflect.Value,
+	i int,
+) (reflect.Value, bool, error) {
+	ok, err := includeField(ctx, v.Type(), v.Type().Field(i))
+	if !ok || err != nil {
+		return reflect.Value{}, false, err
+	}
+
+	return unsafereflect.MakeMutable(v.Field(i)), true, nil
+}
+
+// unexportedFieldError returns the error that occurs when an unexported field
+// is encountered while using the PanicOnUnexportedField strategy.
+func unexportedFieldError(
+	ctx cloneContext,
+	t reflect.Type,
+	f reflect.StructField,
+) error {
+	return ctx.Error("%s", unexportedFieldMessage(t, f))
+}
+
+func unexportedFieldMessage(t reflect.Type, f reflect.StructField) string {
+	return fmt.Sprintf(
+		"struct cannot be cloned due to unexported field (%s.%s), try the dyad.WithUnexportedFieldStrategy() option",
+		t,
+		f.Name,
+	)
+}
+
+// channelError returns the error that occurs when a channel is encountered
+// while using the PanicOnChannel strategy.
+func channelError(ctx cloneContext) error {
+	return ctx.Error("%s", channelMessage)
+}
+
+const channelMessage = "channels cannot be cloned, try the dyad.WithChannelStrategy() option"
@@ -1,9 +1,10 @@
 package dyad_test
 
 import (
+	"strings"
 	"time"
 
-	. "github.com/dogmatiq/dyad"
+	"github.com/dogmatiq/dyad"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -14,7 +15,7 @@ var _ = Describe("func Clone()", func() {
 			value := "<value>"
 
 			src := any(&value)
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 
@@ -24,7 +25,7 @@ var _ = Describe("func Clone()", func() {
 
 		It("handles nil values", func() {
 			var src any
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(BeNil())
 		})
@@ -36,7 +37,7 @@ var _ = Describe("func Clone()", func() {
 				}
 
 				src := any(Source{})
-				Clone(src)
+				dyad.Clone(src)
 			}).To(PanicWith(MatchError(
 				"any(dyad_test.Source): struct cannot be cloned due to unexported field (dyad_test.Source.unexported), try the dyad.WithUnexportedFieldStrategy() option",
 			)))
@@ -48,7 +49,7 @@ var _ = Describe("func Clone()", func() {
 			value := "<value>"
 
 			src := &value
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(*dst).To(Equal(*src))
 
@@ -58,7 +59,7 @@ var _ = Describe("func Clone()", func() {
 
 		It("handles nil values", func() {
 			var src *int
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(BeNil())
 		})
@@ -69,7 +70,7 @@ var _ = Describe("func Clone()", func() {
 					unexported string
 				}
 				src := &Source{}
-				Clone(src)
+				dyad.Clone(src)
 			}).To(PanicWith(MatchError(
 				"*dyad_test.Source: struct cannot be cloned due to unexported field (dyad_test.Source.unexported), try the dyad.WithUnexportedFieldStrategy() option",
 			)))
@@ -79,7 +80,7 @@ var _ = Describe("func Clone()", func() {
 	When("the source value is a slice", func() {
 		It("copies the slice itself", func() {
 			src := []int{1, 2, 3}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 
@@ -91,7 +92,7 @@ var _ = Describe("func Clone()", func() {
 			original := "<value>"
 
 			src := []*string{&original}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 
@@ -101,7 +102,7 @@ var _ = Describe("func Clone()", func() {
 
 		It("handles nil values", func() {
 			var src []int
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(BeNil())
 		})
@@ -113,13 +114,65 @@ var _ = Describe("func Clone()", func() {
 				}
 
 				src := []Elem{{}}
-				Clone(src)
+				dyad.Clone(src)
 			}).To(PanicWith(MatchError(
 				"[]dyad_test.Elem[0]: struct cannot be cloned due to unexported field (dyad_test.Elem.unexported), try the dyad.WithUnexportedFieldStrategy() option",
 			)))
 		})
 	})
 
+	When("the source value is an array", func() {
+		It("copies the elements within the array", func() {
+			original := "<value>"
+
+			src := [1]*string{&original}
+			dst := dyad.Clone(src)
+
+			Expect(dst).To(Equal(src))
+
+			original = "<changed>"
+			Expect(dst).ToNot(Equal(src))
+		})
+
+		It("panics if an element contains an unexported field", func() {
+			Expect(func() {
+				type Elem struct {
+					unexported int
+				}
+
+				src := [1]Elem{}
+				dyad.Clone(src)
+			}).To(PanicWith(MatchError(
+				"[1]dyad_test.Elem[0]: struct cannot be cloned due to unexported field (dyad_test.Elem.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+			)))
+		})
+
+		It("panics if an element is a channel", func() {
+			Expect(func() {
+				src := [1]chan int{make(chan int)}
+				dyad.Clone(src)
+			}).To(PanicWith(MatchError(
+				"[1]chan int[0]: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+			)))
+		})
+
+		It("clones elements according to the unexported field and channel strategies", func() {
+			type Elem struct {
+				Channel    chan int
+				unexported int
+			}
+
+			src := [1]Elem{{make(chan int), 123}}
+			dst := dyad.Clone(
+				src,
+				dyad.WithChannelStrategy(dyad.ShareChannels),
+				dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+			)
+
+			Expect(dst).To(Equal(src))
+		})
+	})
+
 	When("the source value is a map", func() {
 		It("copies the map itself", func() {
 			src := map[string]int{
@@ -127,7 +180,7 @@ var _ = Describe("func Clone()", func() {
 				"two":   2,
 				"three": 3,
 			}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 
@@ -139,7 +192,7 @@ var _ = Describe("func Clone()", func() {
 			key := "<key>"
 
 			src := map[*string]int{&key: 123}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(HaveLen(1))
 
@@ -153,7 +206,7 @@ var _ = Describe("func Clone()", func() {
 			elem := "<value>"
 
 			src := map[string]*string{"<key>": &elem}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 
@@ -163,7 +216,7 @@ var _ = Describe("func Clone()", func() {
 
 		It("handles nil values", func() {
 			var src map[string]int
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(BeNil())
 		})
@@ -175,7 +228,7 @@ var _ = Describe("func Clone()", func() {
 				}
 
 				src := map[Key]int{{}: 123}
-				Clone(src)
+				dyad.Clone(src)
 			}).To(PanicWith(MatchError(
 				`map[dyad_test.Key]int[dyad_test.Key{unexported:""}]: struct cannot be cloned due to unexported field (dyad_test.Key.unexported), try the dyad.WithUnexportedFieldStrategy() option`,
 			)))
@@ -188,7 +241,7 @@ var _ = Describe("func Clone()", func() {
 				}
 
 				src := map[string]Elem{"<key>": {}}
-				Clone(src)
+				dyad.Clone(src)
 			}).To(PanicWith(MatchError(
 				`map[string]dyad_test.Elem["<key>"]: struct cannot be cloned due to unexported field (dyad_test.Elem.unexported), try the dyad.WithUnexportedFieldStrategy() option`,
 			)))
@@ -202,7 +255,7 @@ var _ = Describe("func Clone()", func() {
 			}
 
 			src := Source{"<value>"}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 		})
@@ -217,7 +270,7 @@ var _ = Describe("func Clone()", func() {
 			}
 
 			src := Source{Embedded{"<value>"}}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 		})
@@ -230,7 +283,7 @@ var _ = Describe("func Clone()", func() {
 			}
 
 			src := Source{&original}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 
@@ -246,7 +299,7 @@ var _ = Describe("func Clone()", func() {
 			}
 
 			src := Source{&original}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 
@@ -260,7 +313,7 @@ var _ = Describe("func Clone()", func() {
 			}
 
 			src := Source{}
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 		})
@@ -276,7 +329,7 @@ var _ = Describe("func Clone()", func() {
 				}
 
 				src := Source{}
-				Clone(src)
+				dyad.Clone(src)
 			}).To(PanicWith(MatchError(
 				"dyad_test.Source.Value: struct cannot be cloned due to unexported field (dyad_test.Field.unexported), try the dyad.WithUnexportedFieldStrategy() option",
 			)))
@@ -290,7 +343,7 @@ var _ = Describe("func Clone()", func() {
 					}
 
 					src := Source{"<value>"}
-					Clone(src)
+					dyad.Clone(src)
 				}).To(PanicWith(MatchError(
 					"dyad_test.Source: struct cannot be cloned due to unexported field (dyad_test.Source.unexported), try the dyad.WithUnexportedFieldStrategy() option",
 				)))
@@ -304,9 +357,9 @@ var _ = Describe("func Clone()", func() {
 						}
 
 						src := Source{"<value>"}
-						Clone(
+						dyad.Clone(
 							src,
-							WithUnexportedFieldStrategy(PanicOnUnexportedField),
+							dyad.WithUnexportedFieldStrategy(dyad.PanicOnUnexportedField),
 						)
 					}).To(PanicWith(MatchError(
 						"dyad_test.Source: struct cannot be cloned due to unexported field (dyad_test.Source.unexported), try the dyad.WithUnexportedFieldStrategy() option",
@@ -321,9 +374,9 @@ var _ = Describe("func Clone()", func() {
 					}
 
 					src := Source{"<value>"}
-					dst := Clone(
+					dst := dyad.Clone(
 						src,
-						WithUnexportedFieldStrategy(CloneUnexportedFields),
+						dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
 					)
 
 					Expect(dst).To(Equal(src))
@@ -338,9 +391,9 @@ var _ = Describe("func Clone()", func() {
 					}
 
 					src := Source{"<exported>", "<unexported>"}
-					dst := Clone(
+					dst := dyad.Clone(
 						src,
-						WithUnexportedFieldStrategy(IgnoreUnexportedFields),
+						dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
 					)
 
 					Expect(dst).To(Equal(Source{"<exported>", ""}))
@@ -353,7 +406,7 @@ var _ = Describe("func Clone()", func() {
 		It("panics", func() {
 			Expect(func() {
 				src := make(chan int, 1)
-				Clone(src)
+				dyad.Clone(src)
 			}).To(PanicWith(MatchError(
 				"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
 			)))
@@ -363,9 +416,9 @@ var _ = Describe("func Clone()", func() {
 			It("panics", func() {
 				Expect(func() {
 					src := make(chan int, 1)
-					Clone(
+					dyad.Clone(
 						src,
-						WithChannelStrategy(PanicOnChannel),
+						dyad.WithChannelStrategy(dyad.PanicOnChannel),
 					)
 				}).To(PanicWith(MatchError(
 					"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
@@ -376,9 +429,9 @@ var _ = Describe("func Clone()", func() {
 		When("using the ShareChannel strategy", func() {
 			It("shares the channel with the original value", func() {
 				src := make(chan int, 1)
-				dst := Clone(
+				dst := dyad.Clone(
 					src,
-					WithChannelStrategy(ShareChannels),
+					dyad.WithChannelStrategy(dyad.ShareChannels),
 				)
 
 				Expect(src).To(BeIdenticalTo(dst))
@@ -388,9 +441,9 @@ var _ = Describe("func Clone()", func() {
 		When("using the IgnoreChannel strategy", func() {
 			It("uses a nil value", func() {
 				src := make(chan int, 1)
-				dst := Clone(
+				dst := dyad.Clone(
 					src,
-					WithChannelStrategy(IgnoreChannels),
+					dyad.WithChannelStrategy(dyad.IgnoreChannels),
 				)
 
 				Expect(dst).To(BeNil())
@@ -400,42 +453,112 @@ var _ = Describe("func Clone()", func() {
 
 	When("the source value is a basic type", func() {
 		It("returns the same value", func() {
-			Expect(Clone(true)).To(BeTrue())
+			Expect(dyad.Clone(true)).To(BeTrue())
 
-			Expect(Clone(uintptr(123))).To(Equal(uintptr(123)))
+			Expect(dyad.Clone(uintptr(123))).To(Equal(uintptr(123)))
 
-			Expect(Clone("<string>")).To(Equal("<string>"))
+			Expect(dyad.Clone("<string>")).To(Equal("<string>"))
 
-			Expect(Clone(int(123))).To(Equal(int(123)))
-			Expect(Clone(int8(123))).To(Equal(int8(123)))
-			Expect(Clone(int16(123))).To(Equal(int16(123)))
-			Expect(Clone(int32(123))).To(Equal(int32(123)))
-			Expect(Clone(int64(123))).To(Equal(int64(123)))
+			Expect(dyad.Clone(int(123))).To(Equal(int(123)))
+			Expect(dyad.Clone(int8(123))).To(Equal(int8(123)))
+			Expect(dyad.Clone(int16(123))).To(Equal(int16(123)))
+			Expect(dyad.Clone(int32(123))).To(Equal(int32(123)))
+			Expect(dyad.Clone(int64(123))).To(Equal(int64(123)))
 
-			Expect(Clone(uint(123))).To(Equal(uint(123)))
-			Expect(Clone(uint8(123))).To(Equal(uint8(123)))
-			Expect(Clone(uint16(123))).To(Equal(uint16(123)))
-			Expect(Clone(uint32(123))).To(Equal(uint32(123)))
-			Expect(Clone(uint64(123))).To(Equal(uint64(123)))
+			Expect(dyad.Clone(uint(123))).To(Equal(uint(123)))
+			Expect(dyad.Clone(uint8(123))).To(Equal(uint8(123)))
+			Expect(dyad.Clone(uint16(123))).To(Equal(uint16(123)))
+			Expect(dyad.Clone(uint32(123))).To(Equal(uint32(123)))
+			Expect(dyad.Clone(uint64(123))).To(Equal(uint64(123)))
 
-			Expect(Clone(float32(123.45))).To(Equal(float32(123.45)))
-			Expect(Clone(float64(123.45))).To(Equal(float64(123.45)))
+			Expect(dyad.Clone(float32(123.45))).To(Equal(float32(123.45)))
+			Expect(dyad.Clone(float64(123.45))).To(Equal(float64(123.45)))
 
-			Expect(Clone(complex64(123))).To(Equal(complex64(123)))
-			Expect(Clone(complex128(123))).To(Equal(complex128(123)))
+			Expect(dyad.Clone(complex64(123))).To(Equal(complex64(123)))
+			Expect(dyad.Clone(complex128(123))).To(Equal(complex128(123)))
 		})
 	})
 
 	When("the source value is a time.Time", func() {
 		It("does not clone the location", func() {
 			src := time.Now()
-			dst := Clone(src)
+			dst := dyad.Clone(src)
 
 			Expect(dst).To(Equal(src))
 			Expect(dst.Location()).To(BeIdenticalTo(src.Location()))
 		})
 	})
 
+	When("using the WithTransform option", func() {
+		It("uses the result of the transform in place of the clone", func() {
+			type Source struct {
+				Name  string
+				Names []string
+				Time  time.Time
+			}
+
+			src := Source{
+				Name:  "<name>",
+				Names: []string{"<a>", "<b>"},
+				Time:  time.Date(2001, 2, 3, 4, 5, 6, 7, time.UTC),
+			}
+
+			var paths []string
+			dst := dyad.Clone(
+				src,
+				dyad.WithTransform(func(p dyad.Path, v string) string {
+					paths = append(paths, p.String())
+					return strings.ToUpper(v)
+				}),
+				dyad.WithTransform(func(p dyad.Path, v time.Time) time.Time {
+					return v.Truncate(time.Second)
+				}),
+			)
+
+			Expect(dst).To(Equal(Source{
+				Name:  "<NAME>",
+				Names: []string{"<A>", "<B>"},
+				Time:  time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC),
+			}))
+			Expect(paths).To(Equal([]string{
+				"dyad_test.Source.Name",
+				"dyad_test.Source.Names[0]",
+				"dyad_test.Source.Names[1]",
+			}))
+		})
+
+		It("supports interface types", func() {
+			type Source struct {
+				Value any
+			}
+
+			dst := dyad.Clone(
+				Source{},
+				dyad.WithTransform(func(p dyad.Path, v any) any {
+					return "<replaced>"
+				}),
+			)
+
+			Expect(dst).To(Equal(Source{"<replaced>"}))
+		})
+
+		It("does not recurse into the transformed value", func() {
+			type Source struct {
+				Channel chan int
+			}
+
+			src := Source{make(chan int)}
+			dst := dyad.Clone(
+				src,
+				dyad.WithTransform(func(p dyad.Path, v Source) Source {
+					return Source{}
+				}),
+			)
+
+			Expect(dst).To(Equal(Source{}))
+		})
+	})
+
 	It("xxx", func() {
 		type Source struct {
 			time.Time
@@ -444,7 +567,7 @@ var _ = Describe("func Clone()", func() {
 		src := &Source{
 			Time: time.Now(),
 		}
-		dst := Clone(src)
+		dst := dyad.Clone(src)
 
 		Expect(dst).To(Equal(src))
 	})
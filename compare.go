@@ -0,0 +1,473 @@
+package dyad
+
+import (
+	"reflect"
+	"time"
+)
+
+// comparer finds the differences between two values.
+type comparer struct {
+	// report is called for each difference that is found. It returns false if
+	// the comparison should stop.
+	report func(Difference) bool
+
+	// sortKeys indicates whether map keys should be compared in a
+	// deterministic order.
+	sortKeys bool
+
+	// inProgress is the set of comparisons that have been started but not yet
+	// finished, mapped to the number of differences that had been found when
+	// each was started. It is used to avoid infinite recursion when comparing
+	// cyclic values.
+	inProgress map[comparison]int
+
+	// finished is the set of comparisons that have finished, mapped to whether
+	// any differences were found. Comparisons that found no differences are not
+	// repeated when the same references are encountered at some other path.
+	finished map[comparison]bool
+
+	// differences is the number of differences found so far.
+	differences int
+
+	// stopped is true if report has requested that the comparison stop.
+	stopped bool
+}
+
+// comparison identifies the comparison of two references of the same type.
+//
+// The lengths are included such that slices of the same backing array with
+// different lengths are distinct.
+type comparison struct {
+	a, b       uintptr
+	aLen, bLen int
+	t          reflect.Type
+}
+
+func newComparer(report func(Difference) bool) *comparer {
+	return &comparer{
+		report:     report,
+		inProgress: map[comparison]int{},
+		finished:   map[comparison]bool{},
+	}
+}
+
+func (c *comparer) Compare(
+	ctx cloneContext,
+	a, b reflect.Value,
+) error {
+	switch a.Type() {
+	case timeType:
+		if !a.Interface().(time.Time).Equal(b.Interface().(time.Time)) {
+			c.modified(ctx, a, b)
+		}
+		return nil
+	}
+
+	switch a.Kind() {
+	case reflect.Interface:
+		return c.compareInterface(ctx, a, b)
+	case reflect.Ptr:
+		return c.comparePtr(ctx, a, b)
+	case reflect.Slice:
+		return c.compareSlice(ctx, a, b)
+	case reflect.Array:
+		return c.compareElements(ctx, a, b, a.Len())
+	case reflect.Map:
+		return c.compareMap(ctx, a, b)
+	case reflect.Struct:
+		return c.compareStruct(ctx, a, b)
+	case reflect.Chan:
+		return c.compareChannel(ctx, a, b)
+	case reflect.Func, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			c.modified(ctx, a, b)
+		}
+		return nil
+	default:
+		if !equalBasic(a, b) {
+			c.modified(ctx, a, b)
+		}
+		return nil
+	}
+}
+
+// equalValues returns true if a and b are equal.
+func equalValues(
+	ctx cloneContext,
+	a, b reflect.Value,
+) (bool, error) {
+	eq := true
+	err := newComparer(
+		func(Difference) bool {
+			eq = false
+			return false
+		},
+	).Compare(ctx, a, b)
+
+	return eq, err
+}
+
+func (c *comparer) difference(d Difference) {
+	c.differences++
+
+	if !c.stopped {
+		c.stopped = !c.report(d)
+	}
+}
+
+func (c *comparer) modified(ctx cloneContext, a, b reflect.Value) {
+	c.difference(Difference{
+		Path: ctx.path,
+		Kind: ValueModified,
+		A:    a.Interface(),
+		B:    b.Interface(),
+	})
+}
+
+func (c *comparer) added(ctx cloneContext, b reflect.Value) {
+	c.difference(Difference{
+		Path: ctx.path,
+		Kind: ValueAdded,
+		B:    b.Interface(),
+	})
+}
+
+func (c *comparer) removed(ctx cloneContext, a reflect.Value) {
+	c.difference(Difference{
+		Path: ctx.path,
+		Kind: ValueRemoved,
+		A:    a.Interface(),
+	})
+}
+
+// visit marks the comparison of the references a and b as started. It returns
+// false if the comparison is already in progress, in which case the values are
+// cyclic, or if it has already finished without finding any differences.
+//
+// Comparisons that have already found differences are repeated, such that
+// the differences are reported at each path.
+//
+// If it returns true, leave() must be called once the comparison is finished.
+func (c *comparer) visit(a, b reflect.Value) bool {
+	k := comparisonOf(a, b)
+
+	if _, ok := c.inProgress[k]; ok {
+		return false
+	}
+
+	if differs, ok := c.finished[k]; ok && !differs {
+		return false
+	}
+
+	c.inProgress[k] = c.differences
+	return true
+}
+
+// leave marks the comparison of the references a and b as finished.
+func (c *comparer) leave(a, b reflect.Value) {
+	k := comparisonOf(a, b)
+	c.finished[k] = c.differences > c.inProgress[k]
+	delete(c.inProgress, k)
+}
+
+// comparisonOf returns the comparison of the references a and b.
+func comparisonOf(a, b reflect.Value) comparison {
+	k := comparison{
+		a: a.Pointer(),
+		b: b.Pointer(),
+		t: a.Type(),
+	}
+
+	if a.Kind() == reflect.Slice {
+		k.aLen = a.Len()
+		k.bLen = b.Len()
+	}
+
+	return k
+}
+
+// compareNil compares a and b, which must be nillable, and reports a
+// modification if exactly one of them is nil. It returns true if both values
+// are non-nil and therefore require further comparison.
+func (c *comparer) compareNil(ctx cloneContext, a, b reflect.Value) bool {
+	if a.IsNil() && b.IsNil() {
+		return false
+	}
+
+	if a.IsNil() || b.IsNil() {
+		c.modified(ctx, a, b)
+		return false
+	}
+
+	return true
+}
+
+func (c *comparer) compareInterface(
+	ctx cloneContext,
+	a, b reflect.Value,
+) error {
+	if !c.compareNil(ctx, a, b) {
+		return nil
+	}
+
+	aElem := a.Elem()
+	bElem := b.Elem()
+
+	if aElem.Type() != bElem.Type() {
+		c.modified(ctx, a, b)
+		return nil
+	}
+
+	return c.Compare(
+		ctx.WithType(aElem.Type()),
+		aElem,
+		bElem,
+	)
+}
+
+func (c *comparer) comparePtr(
+	ctx cloneContext,
+	a, b reflect.Value,
+) error {
+	if !c.compareNil(ctx, a, b) {
+		return nil
+	}
+
+	if a.Pointer() == b.Pointer() || !c.visit(a, b) {
+		return nil
+	}
+	defer c.leave(a, b)
+
+	return c.Compare(ctx, a.Elem(), b.Elem())
+}
+
+func (c *comparer) compareSlice(
+	ctx cloneContext,
+	a, b reflect.Value,
+) error {
+	if !c.compareNil(ctx, a, b) {
+		return nil
+	}
+
+	if a.Pointer() == b.Pointer() && a.Len() == b.Len() {
+		return nil
+	}
+
+	if !c.visit(a, b) {
+		return nil
+	}
+	defer c.leave(a, b)
+
+	size := min(a.Len(), b.Len())
+
+	if err := c.compareElements(ctx, a, b, size); err != nil {
+		return err
+	}
+
+	for i := size; i < b.Len(); i++ {
+		c.added(ctx.WithIndex(i), b.Index(i))
+	}
+
+	// Removed elements are reported from last to first, such that the
+	// differences can be applied in order.
+	for i := a.Len() - 1; i >= size; i-- {
+		c.removed(ctx.WithIndex(i), a.Index(i))
+	}
+
+	return nil
+}
+
+// compareElements compares the first n elements of two slices or arrays.
+func (c *comparer) compareElements(
+	ctx cloneContext,
+	a, b reflect.Value,
+	n int,
+) error {
+	for i := 0; i < n && !c.stopped; i++ {
+		if err := c.Compare(
+			ctx.WithIndex(i),
+			a.Index(i),
+			b.Index(i),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *comparer) compareMap(
+	ctx cloneContext,
+	a, b reflect.Value,
+) error {
+	if !c.compareNil(ctx, a, b) {
+		return nil
+	}
+
+	if a.Pointer() == b.Pointer() && a.Len() == b.Len() {
+		return nil
+	}
+
+	if !c.visit(a, b) {
+		return nil
+	}
+	defer c.leave(a, b)
+
+	aKeys := c.mapKeys(a)
+	bKeys := c.mapKeys(b)
+	matched := map[any]struct{}{}
+
+	for _, aKey := range aKeys {
+		if c.stopped {
+			return nil
+		}
+
+		ctx := ctx.WithKey(aKey)
+
+		bKey, ok, err := c.findKey(ctx, aKey, b, bKeys, matched)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			c.removed(ctx, a.MapIndex(aKey))
+			continue
+		}
+
+		if err := c.Compare(
+			ctx,
+			a.MapIndex(aKey),
+			b.MapIndex(bKey),
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, bKey := range bKeys {
+		if _, ok := matched[bKey.Interface()]; !ok {
+			c.added(ctx.WithKey(bKey), b.MapIndex(bKey))
+		}
+	}
+
+	return nil
+}
+
+// mapKeys returns the keys of the map m, sorted by their string
+// representation if c.sortKeys is true.
+func (c *comparer) mapKeys(m reflect.Value) []reflect.Value {
+	if c.sortKeys {
+		return sortedMapKeys(m)
+	}
+
+	return m.MapKeys()
+}
+
+// findKey returns the key within the map m that is equal to k.
+//
+// Keys that are deeply equal are not necessarily identical, such as pointers
+// to equal values, so if m does not contain k itself each of m's keys is
+// compared to k in turn. keys is the set of keys within m, and matched is the
+// set of keys that have already been matched to some other key.
+func (c *comparer) findKey(
+	ctx cloneContext,
+	k, m reflect.Value,
+	keys []reflect.Value,
+	matched map[any]struct{},
+) (reflect.Value, bool, error) {
+	if m.MapIndex(k).IsValid() {
+		matched[k.Interface()] = struct{}{}
+		return k, true, nil
+	}
+
+	for _, candidate := range keys {
+		if _, ok := matched[candidate.Interface()]; ok {
+			continue
+		}
+
+		eq, err := equalValues(ctx, k, candidate)
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+
+		if eq {
+			matched[candidate.Interface()] = struct{}{}
+			return candidate, true, nil
+		}
+	}
+
+	return reflect.Value{}, false, nil
+}
+
+func (c *comparer) compareStruct(
+	ctx cloneContext,
+	a, b reflect.Value,
+) error {
+	size := a.NumField()
+	structType := a.Type()
+
+	for i := 0; i < size && !c.stopped; i++ {
+		field := structType.Field(i)
+
+		aField, ok, err := fieldValue(ctx, a, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		bField, _, _ := fieldValue(ctx, b, i)
+
+		if err := c.Compare(
+			ctx.WithField(field.Name),
+			aField,
+			bField,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *comparer) compareChannel(
+	ctx cloneContext,
+	a, b reflect.Value,
+) error {
+	switch ctx.options.channelStrategy {
+	case ShareChannels:
+		if a.Pointer() != b.Pointer() {
+			c.modified(ctx, a, b)
+		}
+	case IgnoreChannels:
+	default:
+		return channelError(ctx)
+	}
+
+	return nil
+}
+
+// equalBasic compares two values of the same basic type.
+func equalBasic(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return equalFloat(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		x, y := a.Complex(), b.Complex()
+		return equalFloat(real(x), real(y)) && equalFloat(imag(x), imag(y))
+	default: // reflect.String
+		return a.String() == b.String()
+	}
+}
+
+// equalFloat returns true if x and y are equal, or are both NaN, such that NaN
+// is equal to its clone.
+func equalFloat(x, y float64) bool {
+	return x == y || (x != x && y != y)
+}
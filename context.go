@@ -3,35 +3,61 @@ package dyad
 import (
 	"errors"
 	"fmt"
-	"io"
 	"reflect"
-	"strings"
 )
 
 type cloneContext struct {
-	options   cloneOptions
-	writePath func(w io.Writer)
+	options cloneOptions
+	path    Path
+	depth   int
+
+	// identities maps the data referred to by pointers, slices and maps to
+	// their clones. It is nil unless cloning within a Session.
+	identities map[identity]clonedReference
 }
 
-func (c cloneContext) WithPath(
-	format string,
-	args ...any,
-) cloneContext {
-	previous := c.writePath
-	c.writePath = func(w io.Writer) {
-		if previous != nil {
-			previous(w)
-		}
-
-		for i, a := range args {
-			if a, ok := a.(reflect.Type); ok {
-				args[i] = renderTypeName(a)
-			}
-		}
-
-		fmt.Fprintf(w, format, args...)
+func newCloneContext(options []Option) cloneContext {
+	var ctx cloneContext
+
+	for _, o := range options {
+		o(&ctx.options)
 	}
 
+	return ctx
+}
+
+func (c cloneContext) WithRoot(t reflect.Type) cloneContext {
+	c.path = rootPath(t)
+	return c
+}
+
+func (c cloneContext) WithField(name string) cloneContext {
+	c.path = c.path.append(&pathElem{kind: fieldElem, name: name})
+	return c
+}
+
+func (c cloneContext) WithIndex(i int) cloneContext {
+	c.path = c.path.append(&pathElem{kind: indexElem, index: i})
+	return c
+}
+
+func (c cloneContext) WithKey(k reflect.Value) cloneContext {
+	c.path = c.path.append(&pathElem{kind: keyElem, key: k})
+	return c
+}
+
+func (c cloneContext) WithType(t reflect.Type) cloneContext {
+	c.path = c.path.append(&pathElem{kind: typeElem, typ: t})
+	return c
+}
+
+func (c cloneContext) WithAnyElem() cloneContext {
+	c.path = c.path.append(&pathElem{kind: anyElemElem})
+	return c
+}
+
+func (c cloneContext) WithAnyKey() cloneContext {
+	c.path = c.path.append(&pathElem{kind: anyKeyElem})
 	return c
 }
 
@@ -39,13 +65,9 @@ func (c cloneContext) Error(
 	format string,
 	args ...any,
 ) error {
-	message := &strings.Builder{}
-
-	c.writePath(message)
-	message.WriteString(": ")
-	fmt.Fprintf(message, format, args...)
-
-	return errors.New(message.String())
+	return errors.New(
+		c.path.String() + ": " + fmt.Sprintf(format, args...),
+	)
 }
 
 func renderTypeName(t reflect.Type) string {
@@ -0,0 +1,361 @@
+package dyad
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/dogmatiq/dyad/internal/unsafereflect"
+)
+
+// Convert returns a deep copy of src as a value of type Dst.
+//
+// Src and Dst must have the same structure, but may be distinct types. Struct
+// fields are copied to the field of the same name in the destination struct,
+// or the name given by a struct tag when using the WithFieldTag() option.
+// Fields that have no counterpart are left as their zero-value, unless the
+// WithStrictConversion() option is used.
+//
+// Pointers, slices, arrays, maps and interfaces are converted element-wise,
+// and values of the same type are cloned using the same semantics as Clone().
+// Other values are converted to the destination type if they have the same
+// kind, such as between named string types.
+//
+// Unexported fields and channels are handled according to the same strategies
+// used by Clone(). It returns an error under the same circumstances that
+// Clone() would panic, or if some value within src cannot be converted to its
+// destination type. The returned value contains any conversions that were
+// made before the error occurred.
+func Convert[Dst, Src any](src Src, options ...Option) (dst Dst, err error) {
+	srcV := reflect.ValueOf(&src).Elem()
+	dstV := reflect.ValueOf(&dst).Elem()
+
+	c := &converter{}
+
+	if err := c.Convert(
+		newCloneContext(options).WithRoot(srcV.Type()),
+		srcV,
+		dstV,
+	); err != nil {
+		return dst, err
+	}
+
+	return dst, errors.Join(c.unmapped...)
+}
+
+// WithFieldTag is an option that causes Convert() to match struct fields using
+// the name given by the struct tag with the given key, such as "json".
+//
+// The name is the portion of the tag value before the first comma. Fields with
+// a name of "-" are never matched, and fields without the tag are matched by
+// their Go name.
+func WithFieldTag(key string) Option {
+	return func(opts *cloneOptions) {
+		opts.fieldTag = key
+	}
+}
+
+// WithStrictConversion is an option that causes Convert() to return an error
+// if any struct field within the source or destination value has no
+// counterpart in the other.
+//
+// Fields that are skipped using the IgnoreUnexportedFields strategy, or by a
+// field tag of "-", are not considered to be unmapped.
+func WithStrictConversion() Option {
+	return func(opts *cloneOptions) {
+		opts.strictConversion = true
+	}
+}
+
+// converter copies values between types with the same structure.
+type converter struct {
+	// unmapped is a list of errors describing struct fields that have no
+	// counterpart, as reported when using WithStrictConversion().
+	unmapped []error
+}
+
+func (c *converter) Convert(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.Type() == dst.Type() {
+		return cloneInto(ctx, src, dst)
+	}
+
+	if dst.Kind() == reflect.Interface {
+		return c.convertIntoInterface(ctx, src, dst)
+	}
+
+	if src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil
+		}
+
+		return c.Convert(ctx.WithType(src.Elem().Type()), src.Elem(), dst)
+	}
+
+	if src.Kind() != dst.Kind() {
+		return conversionError(ctx, src, dst)
+	}
+
+	switch src.Kind() {
+	case reflect.Ptr:
+		return c.convertPtr(ctx, src, dst)
+	case reflect.Slice:
+		return c.convertSlice(ctx, src, dst)
+	case reflect.Array:
+		return c.convertArray(ctx, src, dst)
+	case reflect.Map:
+		return c.convertMap(ctx, src, dst)
+	case reflect.Struct:
+		return c.convertStruct(ctx, src, dst)
+	case reflect.Chan:
+		return conversionError(ctx, src, dst)
+	default:
+		if !src.CanConvert(dst.Type()) {
+			return conversionError(ctx, src, dst)
+		}
+
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+}
+
+func (c *converter) convertIntoInterface(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil
+		}
+
+		src = src.Elem()
+		ctx = ctx.WithType(src.Type())
+	}
+
+	if !src.Type().AssignableTo(dst.Type()) {
+		return conversionError(ctx, src, dst)
+	}
+
+	value := reflect.New(src.Type()).Elem()
+	if err := cloneInto(ctx, src, value); err != nil {
+		return err
+	}
+
+	dst.Set(value)
+
+	return nil
+}
+
+func (c *converter) convertPtr(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	ptr := reflect.New(dst.Type().Elem())
+	if err := c.Convert(ctx, src.Elem(), ptr.Elem()); err != nil {
+		return err
+	}
+
+	dst.Set(ptr)
+
+	return nil
+}
+
+func (c *converter) convertSlice(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	dst.Set(
+		reflect.MakeSlice(
+			dst.Type(),
+			src.Len(),
+			src.Cap(),
+		),
+	)
+
+	return c.convertElements(ctx, src, dst)
+}
+
+func (c *converter) convertArray(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.Len() != dst.Len() {
+		return conversionError(ctx, src, dst)
+	}
+
+	return c.convertElements(ctx, src, dst)
+}
+
+func (c *converter) convertElements(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	for i := 0; i < src.Len(); i++ {
+		if err := c.Convert(
+			ctx.WithIndex(i),
+			src.Index(i),
+			dst.Index(i),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertMap(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	mapType := dst.Type()
+
+	dst.Set(
+		reflect.MakeMapWithSize(mapType, src.Len()),
+	)
+
+	for _, srcKey := range sortedMapKeys(src) {
+		ctx := ctx.WithKey(srcKey)
+
+		dstKey := reflect.New(mapType.Key()).Elem()
+		if err := c.Convert(ctx, srcKey, dstKey); err != nil {
+			return err
+		}
+
+		dstElem := reflect.New(mapType.Elem()).Elem()
+		if err := c.Convert(ctx, src.MapIndex(srcKey), dstElem); err != nil {
+			return err
+		}
+
+		dst.SetMapIndex(dstKey, dstElem)
+	}
+
+	return nil
+}
+
+func (c *converter) convertStruct(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	srcType := src.Type()
+	dstType := dst.Type()
+
+	// Build an index of the destination fields by the name used to match them
+	// with the source fields.
+	dstFields := map[string]int{}
+	var dstNames []string
+
+	for i := 0; i < dstType.NumField(); i++ {
+		field := dstType.Field(i)
+
+		if ok, err := includeField(ctx, dstType, field); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		if name, ok := conversionFieldName(ctx, field); ok {
+			dstFields[name] = i
+			dstNames = append(dstNames, name)
+		}
+	}
+
+	mapped := map[string]struct{}{}
+
+	for i := 0; i < srcType.NumField(); i++ {
+		field := srcType.Field(i)
+
+		srcField, ok, err := fieldValue(ctx, src, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		name, ok := conversionFieldName(ctx, field)
+		if !ok {
+			continue
+		}
+
+		ctx := ctx.WithField(field.Name)
+
+		index, ok := dstFields[name]
+		if !ok {
+			if ctx.options.strictConversion {
+				c.unmapped = append(
+					c.unmapped,
+					ctx.Error("field has no counterpart in %s", dstType),
+				)
+			}
+			continue
+		}
+
+		mapped[name] = struct{}{}
+
+		if err := c.Convert(
+			ctx,
+			srcField,
+			unsafereflect.MakeMutable(dst.Field(index)),
+		); err != nil {
+			return err
+		}
+	}
+
+	if ctx.options.strictConversion {
+		for _, name := range dstNames {
+			if _, ok := mapped[name]; !ok {
+				c.unmapped = append(
+					c.unmapped,
+					ctx.Error(
+						"%s.%s has no counterpart in %s",
+						dstType,
+						dstType.Field(dstFields[name]).Name,
+						srcType,
+					),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// conversionFieldName returns the name used to match f with a field in another
+// struct, or false if f is never matched.
+func conversionFieldName(ctx cloneContext, f reflect.StructField) (string, bool) {
+	if key := ctx.options.fieldTag; key != "" {
+		if tag, ok := f.Tag.Lookup(key); ok {
+			name, _, _ := strings.Cut(tag, ",")
+
+			if name == "-" {
+				return "", false
+			}
+
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+
+	return f.Name, true
+}
+
+// conversionError returns the error that occurs when src cannot be converted
+// to the type of dst.
+func conversionError(ctx cloneContext, src, dst reflect.Value) error {
+	return ctx.Error("cannot convert %s to %s", src.Type(), dst.Type())
+}
@@ -0,0 +1,168 @@
+package dyad_test
+
+import (
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Convert()", func() {
+	type Status string
+
+	type DomainItem struct {
+		SKU      string
+		Quantity int
+	}
+
+	type Domain struct {
+		ID        string
+		Status    Status
+		Items     []*DomainItem
+		Index     map[string]DomainItem
+		Primary   *DomainItem
+		Metadata  any
+		CreatedAt time.Time
+		Internal  string
+	}
+
+	type APIItem struct {
+		SKU      string
+		Quantity int
+	}
+
+	type API struct {
+		ID        string
+		Status    string
+		Items     []*APIItem
+		Index     map[string]APIItem
+		Primary   *APIItem
+		Metadata  any
+		CreatedAt time.Time
+		Extra     string
+	}
+
+	It("copies fields by name between structurally compatible types", func() {
+		createdAt := time.Now()
+		src := Domain{
+			ID:        "<id>",
+			Status:    "active",
+			Items:     []*DomainItem{{"<a>", 1}, nil},
+			Index:     map[string]DomainItem{"<b>": {"<b>", 2}},
+			Primary:   &DomainItem{"<c>", 3},
+			Metadata:  []int{4},
+			CreatedAt: createdAt,
+			Internal:  "<internal>",
+		}
+
+		dst, err := dyad.Convert[API](src)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(API{
+			ID:        "<id>",
+			Status:    "active",
+			Items:     []*APIItem{{"<a>", 1}, nil},
+			Index:     map[string]APIItem{"<b>": {"<b>", 2}},
+			Primary:   &APIItem{"<c>", 3},
+			Metadata:  []int{4},
+			CreatedAt: createdAt,
+		}))
+	})
+
+	It("does not share data with the source value", func() {
+		src := Domain{Metadata: []int{1}}
+
+		dst, err := dyad.Convert[API](src)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		src.Metadata.([]int)[0] = 2
+		Expect(dst.Metadata).To(Equal([]int{1}))
+	})
+
+	It("returns an error if a value cannot be converted", func() {
+		type Other struct {
+			ID int
+		}
+
+		_, err := dyad.Convert[Other](Domain{})
+
+		Expect(err).To(MatchError(
+			"dyad_test.Domain.ID: cannot convert string to int",
+		))
+	})
+
+	When("using the WithFieldTag option", func() {
+		It("matches fields using the tag", func() {
+			type Tagged struct {
+				Identifier string `api:"ID"`
+				Ignored    string `api:"-"`
+				Status     Status `api:",omitempty"`
+			}
+
+			dst, err := dyad.Convert[Tagged](
+				Domain{ID: "<id>", Status: "active"},
+				dyad.WithFieldTag("api"),
+			)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(dst).To(Equal(Tagged{
+				Identifier: "<id>",
+				Status:     "active",
+			}))
+		})
+	})
+
+	When("using the WithStrictConversion option", func() {
+		It("returns an error describing each unmapped field", func() {
+			dst, err := dyad.Convert[API](
+				Domain{ID: "<id>"},
+				dyad.WithStrictConversion(),
+			)
+
+			Expect(err).To(MatchError(
+				"dyad_test.Domain.Internal: field has no counterpart in dyad_test.API\n" +
+					"dyad_test.Domain: dyad_test.API.Extra has no counterpart in dyad_test.Domain",
+			))
+			Expect(dst.ID).To(Equal("<id>"))
+		})
+
+		It("does not return an error if every field is mapped", func() {
+			_, err := dyad.Convert[APIItem](
+				DomainItem{},
+				dyad.WithStrictConversion(),
+			)
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	It("honours the unexported field strategy", func() {
+		type Source struct {
+			value int
+		}
+
+		type Target struct {
+			value int
+		}
+
+		_, err := dyad.Convert[Target](Source{1})
+		Expect(err).To(MatchError(
+			"dyad_test.Source: struct cannot be cloned due to unexported field (dyad_test.Target.value), try the dyad.WithUnexportedFieldStrategy() option",
+		))
+
+		dst, err := dyad.Convert[Target](
+			Source{1},
+			dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(Target{1}))
+
+		dst, err = dyad.Convert[Target](
+			Source{1},
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(Target{}))
+	})
+})
@@ -0,0 +1,96 @@
+package dyad
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Diff returns the differences between a and b.
+//
+// It traverses values in the same way as Equal(), and returns an empty slice
+// if Equal(a, b) is true. Each difference describes the path to the value
+// that differs, in the same format used within error messages.
+//
+// Additions and removals are reported for the elements of slices and maps.
+// Elements removed from the end of a slice are reported from last to first,
+// such that the differences can be applied in order. All other differences,
+// including changes to the dynamic type of an interface or a pointer changing
+// to or from nil, are reported as modifications.
+func Diff[T any](a, b T, options ...Option) []Difference {
+	diff, err := diff(a, b, options)
+	if err != nil {
+		panic(err)
+	}
+
+	return diff
+}
+
+func diff[T any](a, b T, options []Option) ([]Difference, error) {
+	var diff []Difference
+
+	c := newComparer(
+		func(d Difference) bool {
+			diff = append(diff, d)
+			return true
+		},
+	)
+	c.sortKeys = true
+
+	aV := reflect.ValueOf(&a).Elem()
+	bV := reflect.ValueOf(&b).Elem()
+
+	err := c.Compare(
+		newCloneContext(options).WithRoot(aV.Type()),
+		aV,
+		bV,
+	)
+
+	return diff, err
+}
+
+// Difference describes a difference between two values.
+type Difference struct {
+	// Path is the path to the value that differs.
+	Path Path
+
+	// Kind is the kind of difference.
+	Kind DifferenceKind
+
+	// A is the value from the first of the compared values. It is nil if Kind
+	// is ValueAdded.
+	A any
+
+	// B is the value from the second of the compared values. It is nil if Kind
+	// is ValueRemoved.
+	B any
+}
+
+// String returns a human-readable description of the difference.
+func (d Difference) String() string {
+	switch d.Kind {
+	case ValueAdded:
+		return fmt.Sprintf("%s: added %#v", d.Path, d.B)
+	case ValueRemoved:
+		return fmt.Sprintf("%s: removed %#v", d.Path, d.A)
+	default:
+		return fmt.Sprintf("%s: modified %#v -> %#v", d.Path, d.A, d.B)
+	}
+}
+
+// DifferenceKind is an enumeration of the kinds of difference that can occur
+// between two values.
+type DifferenceKind int
+
+const (
+	// ValueModified indicates that the value differs between the compared
+	// values.
+	ValueModified DifferenceKind = iota
+
+	// ValueAdded indicates that a slice or map element is present in the
+	// second of the compared values, but not the first.
+	ValueAdded
+
+	// ValueRemoved indicates that a slice or map element is present in the
+	// first of the compared values, but not the second.
+	ValueRemoved
+)
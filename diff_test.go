@@ -0,0 +1,148 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Diff()", func() {
+	type Value struct {
+		Name      string
+		Tags      []string
+		Attrs     map[string]int
+		Interface any
+	}
+
+	render := func(diff []dyad.Difference) []string {
+		var lines []string
+		for _, d := range diff {
+			lines = append(lines, d.String())
+		}
+		return lines
+	}
+
+	It("returns an empty slice if the values are equal", func() {
+		src := Value{
+			Name:  "<name>",
+			Tags:  []string{"<tag>"},
+			Attrs: map[string]int{"<attr>": 1},
+		}
+
+		Expect(dyad.Diff(src, dyad.Clone(src))).To(BeEmpty())
+	})
+
+	It("reports the path to each difference", func() {
+		a := Value{
+			Name:      "<name>",
+			Tags:      []string{"<a>", "<b>", "<c>"},
+			Attrs:     map[string]int{"one": 1, "two": 2},
+			Interface: 1,
+		}
+
+		b := Value{
+			Name:      "<changed>",
+			Tags:      []string{"<a>"},
+			Attrs:     map[string]int{"two": 22, "three": 3},
+			Interface: "1",
+		}
+
+		var diff []string
+		for _, d := range dyad.Diff(a, b) {
+			diff = append(diff, d.String())
+		}
+
+		Expect(diff).To(Equal([]string{
+			`dyad_test.Value.Name: modified "<name>" -> "<changed>"`,
+			`dyad_test.Value.Tags[2]: removed "<c>"`,
+			`dyad_test.Value.Tags[1]: removed "<b>"`,
+			`dyad_test.Value.Attrs["one"]: removed 1`,
+			`dyad_test.Value.Attrs["two"]: modified 2 -> 22`,
+			`dyad_test.Value.Attrs["three"]: added 3`,
+			`dyad_test.Value.Interface: modified 1 -> "1"`,
+		}))
+	})
+
+	It("reports the kind of each difference and both values", func() {
+		diff := dyad.Diff([]int{1}, []int{2, 3})
+
+		Expect(diff).To(HaveLen(2))
+
+		Expect(diff[0].Path.String()).To(Equal("[]int[0]"))
+		Expect(diff[0].Kind).To(Equal(dyad.ValueModified))
+		Expect(diff[0].A).To(Equal(1))
+		Expect(diff[0].B).To(Equal(2))
+
+		Expect(diff[1].Path.String()).To(Equal("[]int[1]"))
+		Expect(diff[1].Kind).To(Equal(dyad.ValueAdded))
+		Expect(diff[1].A).To(BeNil())
+		Expect(diff[1].B).To(Equal(3))
+	})
+
+	It("supports cyclic values of different lengths", func() {
+		aSlice := []any{nil}
+		aSlice[0] = aSlice
+
+		bSlice := []any{nil, 1}
+		bSlice[0] = bSlice
+
+		Expect(render(dyad.Diff(aSlice, bSlice))).To(Equal([]string{
+			"[]interface {}[1]: added 1",
+		}))
+
+		aMap := map[string]any{}
+		aMap["self"] = aMap
+
+		bMap := map[string]any{"other": 1}
+		bMap["self"] = bMap
+
+		Expect(render(dyad.Diff(aMap, bMap))).To(Equal([]string{
+			`map[string]interface {}["other"]: added 1`,
+		}))
+	})
+
+	It("reports differences in data that is referred to at multiple paths", func() {
+		type T struct {
+			P *int
+			M map[string]*int
+		}
+
+		x, y := 1, 2
+
+		a := T{&x, map[string]*int{"x": &x}}
+		b := T{&y, map[string]*int{"x": &y}}
+
+		Expect(render(dyad.Diff(a, b))).To(Equal([]string{
+			`dyad_test.T.P: modified 1 -> 2`,
+			`dyad_test.T.M["x"]: modified 1 -> 2`,
+		}))
+	})
+
+	It("reports differences in shared data at each path", func() {
+		type Node struct {
+			L, R  *Node
+			Value int
+		}
+
+		a := &Node{Value: 1}
+		b := &Node{Value: 2}
+
+		Expect(render(dyad.Diff(
+			&Node{L: &Node{L: a, R: a}, R: &Node{L: a, R: a}},
+			&Node{L: &Node{L: b, R: b}, R: &Node{L: b, R: b}},
+		))).To(Equal([]string{
+			`*dyad_test.Node.L.L.Value: modified 1 -> 2`,
+			`*dyad_test.Node.L.R.Value: modified 1 -> 2`,
+			`*dyad_test.Node.R.L.Value: modified 1 -> 2`,
+			`*dyad_test.Node.R.R.Value: modified 1 -> 2`,
+		}))
+	})
+
+	It("panics if the values cannot be compared", func() {
+		Expect(func() {
+			dyad.Diff(make(chan int), make(chan int))
+		}).To(PanicWith(MatchError(
+			"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		)))
+	})
+})
@@ -0,0 +1,281 @@
+package dyad
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// WriteDOT writes a graph of v to w in the Graphviz DOT language.
+//
+// Each node in the graph represents the root value, or the data referred to
+// by a pointer, slice, map or channel within v. Structs, arrays and the values
+// within interfaces are rendered within the node that contains them, along
+// with any scalar values. Edges are labelled with the path from one node to
+// the next, such as a field name, index or map key.
+//
+// Nodes that are referred to by more than one edge are highlighted, as are
+// edges that form a cycle and the nodes they refer to.
+//
+// It traverses values in the same way as Walk(), and returns an error under
+// the same circumstances.
+func WriteDOT[T any](w io.Writer, v T, options ...Option) error {
+	g := &dotGraph{
+		nodes: map[dotKey]*dotNode{},
+	}
+
+	if err := Walk(v, g.visit, options...); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, g.String())
+	return err
+}
+
+// dotGraph is a graph of a value, built by walking it.
+type dotGraph struct {
+	nodes map[dotKey]*dotNode
+	order []*dotNode
+	edges []dotEdge
+
+	// stack is the list of nodes that contain the value currently being
+	// visited, from the root outwards.
+	stack []*dotNode
+}
+
+// dotKey identifies the data represented by a node.
+//
+// Slices are identified by their length as well as their backing array, such
+// that slices of the same array with different lengths are distinct nodes.
+type dotKey struct {
+	ref reference
+	len int
+}
+
+// dotNode is a node within a dotGraph.
+type dotNode struct {
+	id    int
+	label []string
+
+	// anchor is the last element of the path at which the node was first
+	// visited. The paths of the values within the node all contain this
+	// element.
+	anchor *pathElem
+
+	// inDegree is the number of edges that refer to the node.
+	inDegree int
+
+	// cyclic is true if the node is referred to by an edge that forms a cycle.
+	cyclic bool
+}
+
+// dotEdge is an edge between two nodes within a dotGraph.
+type dotEdge struct {
+	from, to *dotNode
+	label    string
+	cyclic   bool
+}
+
+// visit is a Visitor that adds v to the graph.
+func (g *dotGraph) visit(p Path, v reflect.Value, k reflect.Kind) WalkAction {
+	if len(g.stack) == 0 {
+		// If the root value is itself a reference, its node represents the
+		// data it refers to, otherwise it contains the root value.
+		g.push(g.newNode(v, p))
+
+		if isDOTReference(k) && !v.IsNil() {
+			return ContinueWalk
+		}
+	}
+
+	// Pop any nodes that do not contain p, as Walk() does not report when it
+	// has finished visiting a value.
+	for !within(p, g.stack[len(g.stack)-1].anchor) {
+		g.stack = g.stack[:len(g.stack)-1]
+	}
+
+	node := g.stack[len(g.stack)-1]
+	rel := relativePath(p, node.anchor)
+
+	if v.Type() == timeType {
+		node.addLine(rel, "%v", v.Interface())
+		return ContinueWalk
+	}
+
+	switch k {
+	case reflect.Interface:
+		if v.IsNil() {
+			node.addLine(rel, "nil")
+		}
+		return ContinueWalk
+
+	case reflect.Struct, reflect.Array:
+		return ContinueWalk
+
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan:
+		if v.IsNil() {
+			node.addLine(rel, "nil")
+			return ContinueWalk
+		}
+
+		if to, ok := g.nodes[dotKeyOf(v)]; ok {
+			cyclic := g.onStack(to)
+			to.cyclic = to.cyclic || cyclic
+			g.addEdge(node, to, rel, cyclic)
+			return SkipChildren
+		}
+
+		to := g.newNode(v, p)
+		g.addEdge(node, to, rel, false)
+		g.push(to)
+		return ContinueWalk
+
+	default:
+		node.addLine(rel, "%#v", v.Interface())
+		return ContinueWalk
+	}
+}
+
+// isDOTReference returns true if values of kind k are rendered as separate
+// nodes.
+func isDOTReference(k reflect.Kind) bool {
+	switch k {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan:
+		return true
+	default:
+		return false
+	}
+}
+
+// dotKeyOf returns the key that identifies the data referred to by v.
+func dotKeyOf(v reflect.Value) dotKey {
+	k := dotKey{ref: referenceTo(v)}
+	if v.Kind() == reflect.Slice {
+		k.len = v.Len()
+	}
+	return k
+}
+
+// newNode adds a node for v, which is first visited at p.
+func (g *dotGraph) newNode(v reflect.Value, p Path) *dotNode {
+	t := v.Type()
+	if v.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	n := &dotNode{
+		id:     len(g.order),
+		label:  []string{renderTypeName(t)},
+		anchor: p.last,
+	}
+
+	if isDOTReference(v.Kind()) && !v.IsNil() {
+		g.nodes[dotKeyOf(v)] = n
+	}
+
+	g.order = append(g.order, n)
+
+	return n
+}
+
+func (g *dotGraph) push(n *dotNode) {
+	g.stack = append(g.stack, n)
+}
+
+// onStack returns true if n contains the value currently being visited.
+func (g *dotGraph) onStack(n *dotNode) bool {
+	for _, x := range g.stack {
+		if x == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *dotGraph) addEdge(from, to *dotNode, label string, cyclic bool) {
+	to.inDegree++
+	g.edges = append(g.edges, dotEdge{from, to, label, cyclic})
+}
+
+// addLine adds a line describing a value within the node to its label.
+func (n *dotNode) addLine(rel, format string, args ...any) {
+	n.label = append(n.label, strings.TrimSpace(rel+" = "+fmt.Sprintf(format, args...)))
+}
+
+// String returns the DOT representation of the graph.
+func (g *dotGraph) String() string {
+	w := &strings.Builder{}
+
+	w.WriteString("digraph {\n")
+	w.WriteString("\tnode [shape=box fontname=monospace];\n")
+
+	for _, n := range g.order {
+		var label strings.Builder
+		for _, line := range n.label {
+			label.WriteString(escapeDOT(line))
+			label.WriteString(`\l`)
+		}
+
+		fmt.Fprintf(w, "\tn%d [label=\"%s\"", n.id, label.String())
+
+		if n.inDegree > 1 {
+			w.WriteString(" style=filled fillcolor=lightyellow")
+		}
+
+		if n.cyclic {
+			w.WriteString(" color=red")
+		}
+
+		w.WriteString("];\n")
+	}
+
+	for _, e := range g.edges {
+		fmt.Fprintf(w, "\tn%d -> n%d [label=\"%s\"", e.from.id, e.to.id, escapeDOT(e.label))
+
+		if e.cyclic {
+			w.WriteString(" color=red style=dashed")
+		}
+
+		w.WriteString("];\n")
+	}
+
+	w.WriteString("}\n")
+
+	return w.String()
+}
+
+// escapeDOT escapes s for use within a quoted DOT string.
+func escapeDOT(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// within returns true if p contains the path element e.
+func within(p Path, e *pathElem) bool {
+	for x := p.last; x != nil; x = x.parent {
+		if x == e {
+			return true
+		}
+	}
+	return false
+}
+
+// relativePath returns the string representation of the elements of p that
+// follow e.
+func relativePath(p Path, e *pathElem) string {
+	var elems []*pathElem
+	for x := p.last; x != e; x = x.parent {
+		elems = append(elems, x)
+	}
+
+	w := &strings.Builder{}
+	for i := len(elems) - 1; i >= 0; i-- {
+		elems[i].write(w)
+	}
+
+	return w.String()
+}
@@ -0,0 +1,95 @@
+package dyad_test
+
+import (
+	"strings"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func WriteDOT()", func() {
+	It("writes a node for each referenced value", func() {
+		type Inner struct {
+			Value int
+		}
+
+		type Value struct {
+			Name      string
+			Inner     *Inner
+			Slice     []string
+			Map       map[string]bool
+			Interface any
+			Nil       *Inner
+		}
+
+		v := Value{
+			Name:      "<name>",
+			Inner:     &Inner{1},
+			Slice:     []string{"<a>"},
+			Map:       map[string]bool{"<key>": true},
+			Interface: 1.5,
+		}
+
+		var w strings.Builder
+		err := dyad.WriteDOT(&w, v)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(w.String()).To(Equal(
+			"digraph {\n" +
+				"\tnode [shape=box fontname=monospace];\n" +
+				`	n0 [label="dyad_test.Value\l.Name = \"<name>\"\l.Interface(float64) = 1.5\l.Nil = nil\l"];` + "\n" +
+				`	n1 [label="dyad_test.Inner\l.Value = 1\l"];` + "\n" +
+				`	n2 [label="[]string\l[0] = \"<a>\"\l"];` + "\n" +
+				`	n3 [label="map[string]bool\l[\"<key>\"] = true\l"];` + "\n" +
+				`	n0 -> n1 [label=".Inner"];` + "\n" +
+				`	n0 -> n2 [label=".Slice"];` + "\n" +
+				`	n0 -> n3 [label=".Map"];` + "\n" +
+				"}\n",
+		))
+	})
+
+	It("highlights shared nodes and cycles", func() {
+		type Node struct {
+			Value int
+			Next  *Node
+			Other *Node
+		}
+
+		a := &Node{Value: 1}
+		b := &Node{Value: 2}
+		a.Next = b
+		a.Other = b
+		b.Next = a
+
+		var w strings.Builder
+		err := dyad.WriteDOT(&w, a)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(w.String()).To(Equal(
+			"digraph {\n" +
+				"\tnode [shape=box fontname=monospace];\n" +
+				`	n0 [label="dyad_test.Node\l.Value = 1\l" color=red];` + "\n" +
+				`	n1 [label="dyad_test.Node\l.Value = 2\l.Other = nil\l" style=filled fillcolor=lightyellow];` + "\n" +
+				`	n0 -> n1 [label=".Next"];` + "\n" +
+				`	n1 -> n0 [label=".Next" color=red style=dashed];` + "\n" +
+				`	n0 -> n1 [label=".Other"];` + "\n" +
+				"}\n",
+		))
+	})
+
+	It("returns an error if the value cannot be cloned", func() {
+		var w strings.Builder
+		err := dyad.WriteDOT(&w, make(chan int))
+
+		Expect(err).To(MatchError(
+			"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+		Expect(w.String()).To(BeEmpty())
+	})
+
+	It("returns an error if the graph cannot be written", func() {
+		err := dyad.WriteDOT(failingWriter{}, 123)
+		Expect(err).To(MatchError("<error>"))
+	})
+})
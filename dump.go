@@ -0,0 +1,158 @@
+package dyad
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Dump writes a human-readable representation of v to w.
+//
+// Each value within v is written on its own line, prefixed by its path. The
+// paths use the same syntax as the errors produced by Clone(), such that the
+// value at the path reported in an error can be found within the output.
+//
+// Pointers, slices and maps that refer to the same data as some other pointer,
+// slice or map within v are labelled with a reference number, such as "#1".
+// Subsequent occurrences, including those that form a cycle, are written as
+// "-> #1" instead of repeating the data.
+//
+// Unexported fields and channels are included by default, as though the
+// CloneUnexportedFields and ShareChannels strategies were in use. Otherwise, it
+// returns an error under the same circumstances as Walk().
+func Dump[T any](w io.Writer, v T, options ...Option) error {
+	options = append(
+		[]Option{
+			WithUnexportedFieldStrategy(CloneUnexportedFields),
+			WithChannelStrategy(ShareChannels),
+		},
+		options...,
+	)
+
+	d := &dumper{
+		w:      w,
+		counts: map[reference]int{},
+		labels: map[reference]int{},
+	}
+
+	if err := Walk(v, d.count, options...); err != nil {
+		return err
+	}
+
+	if err := Walk(v, d.dump, options...); err != nil {
+		return err
+	}
+
+	return d.err
+}
+
+// dumper writes a human-readable representation of a value.
+type dumper struct {
+	w io.Writer
+
+	// counts is the number of times each reference occurs within the value.
+	counts map[reference]int
+
+	// labels is the reference number assigned to each reference that has
+	// already been written.
+	labels map[reference]int
+
+	// err is the first error that occurred while writing to w.
+	err error
+}
+
+// count is a Visitor that counts the number of times each reference occurs.
+func (d *dumper) count(p Path, v reflect.Value, k reflect.Kind) WalkAction {
+	if !isReference(k) || v.IsNil() {
+		return ContinueWalk
+	}
+
+	ref := referenceTo(v)
+	d.counts[ref]++
+
+	if d.counts[ref] > 1 {
+		return SkipChildren
+	}
+
+	return ContinueWalk
+}
+
+// dump is a Visitor that writes each value.
+func (d *dumper) dump(p Path, v reflect.Value, k reflect.Kind) WalkAction {
+	if isReference(k) && !v.IsNil() {
+		ref := referenceTo(v)
+
+		if d.counts[ref] > 1 {
+			if n, ok := d.labels[ref]; ok {
+				if action := d.write(p, "-> #%d", n); action != ContinueWalk {
+					return action
+				}
+				return SkipChildren
+			}
+
+			n := len(d.labels) + 1
+			d.labels[ref] = n
+
+			if action := d.write(p, "#%d", n); action != ContinueWalk {
+				return action
+			}
+		}
+	}
+
+	if v.Type() == timeType {
+		return d.write(p, "= %v", v.Interface())
+	}
+
+	switch k {
+	case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return d.write(p, "= nil")
+		}
+		if k == reflect.Slice || k == reflect.Map {
+			return d.writeEmpty(p, v, v.Len())
+		}
+		return ContinueWalk
+	case reflect.Array:
+		return d.writeEmpty(p, v, v.Len())
+	case reflect.Struct:
+		return d.writeEmpty(p, v, v.NumField())
+	default:
+		return d.write(p, "= %#v", v.Interface())
+	}
+}
+
+// writeEmpty writes v if it does not contain any values, which is determined
+// by n, the number of values it contains.
+func (d *dumper) writeEmpty(p Path, v reflect.Value, n int) WalkAction {
+	if n != 0 {
+		return ContinueWalk
+	}
+
+	return d.write(p, "= %s{}", renderTypeName(v.Type()))
+}
+
+// write writes a line for the value at p.
+func (d *dumper) write(p Path, format string, args ...any) WalkAction {
+	if _, err := fmt.Fprintf(
+		d.w,
+		"%s %s\n",
+		p,
+		fmt.Sprintf(format, args...),
+	); err != nil {
+		d.err = err
+		return StopWalk
+	}
+
+	return ContinueWalk
+}
+
+// isReference returns true if values of kind k refer to other data that may be
+// shared by other values.
+func isReference(k reflect.Kind) bool {
+	switch k {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,131 @@
+package dyad_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Dump()", func() {
+	type Value struct {
+		Name      string
+		Ptr       *int
+		Slice     []string
+		Map       map[string]bool
+		Interface any
+		Time      time.Time
+		Empty     []int
+		Nil       *int
+		private   int
+	}
+
+	It("writes each value along with its path", func() {
+		n := 123
+		src := Value{
+			Name:      "<name>",
+			Ptr:       &n,
+			Slice:     []string{"<a>", "<b>"},
+			Map:       map[string]bool{"y": true, "x": false},
+			Interface: 1.5,
+			Time:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Empty:     []int{},
+			private:   456,
+		}
+
+		var w strings.Builder
+		err := dyad.Dump(&w, src)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(w.String()).To(Equal(
+			`dyad_test.Value.Name = "<name>"` + "\n" +
+				`dyad_test.Value.Ptr = 123` + "\n" +
+				`dyad_test.Value.Slice[0] = "<a>"` + "\n" +
+				`dyad_test.Value.Slice[1] = "<b>"` + "\n" +
+				`dyad_test.Value.Map["x"] = false` + "\n" +
+				`dyad_test.Value.Map["y"] = true` + "\n" +
+				`dyad_test.Value.Interface(float64) = 1.5` + "\n" +
+				`dyad_test.Value.Time = 2024-01-02 03:04:05 +0000 UTC` + "\n" +
+				`dyad_test.Value.Empty = []int{}` + "\n" +
+				`dyad_test.Value.Nil = nil` + "\n" +
+				`dyad_test.Value.private = 456` + "\n",
+		))
+	})
+
+	It("labels shared references", func() {
+		type Shared struct {
+			A, B *int
+		}
+
+		n := 123
+
+		var w strings.Builder
+		err := dyad.Dump(&w, Shared{&n, &n})
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(w.String()).To(Equal(
+			"dyad_test.Shared.A #1\n" +
+				"dyad_test.Shared.A = 123\n" +
+				"dyad_test.Shared.B -> #1\n",
+		))
+	})
+
+	It("labels cyclic references", func() {
+		type Node struct {
+			Value int
+			Next  *Node
+		}
+
+		n := &Node{Value: 1}
+		n.Next = n
+
+		var w strings.Builder
+		err := dyad.Dump(&w, n)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(w.String()).To(Equal(
+			"*dyad_test.Node #1\n" +
+				"*dyad_test.Node.Value = 1\n" +
+				"*dyad_test.Node.Next -> #1\n",
+		))
+	})
+
+	It("writes channels by default", func() {
+		ch := make(chan int)
+
+		var w strings.Builder
+		err := dyad.Dump(&w, ch)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(w.String()).To(Equal(fmt.Sprintf("chan int = %#v\n", ch)))
+	})
+
+	It("returns an error if the value cannot be cloned", func() {
+		var w strings.Builder
+		err := dyad.Dump(
+			&w,
+			make(chan int),
+			dyad.WithChannelStrategy(dyad.PanicOnChannel),
+		)
+
+		Expect(err).To(MatchError(
+			"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+		Expect(w.String()).To(BeEmpty())
+	})
+
+	It("returns an error if the value cannot be written", func() {
+		err := dyad.Dump(failingWriter{}, 123)
+		Expect(err).To(MatchError("<error>"))
+	})
+})
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("<error>")
+}
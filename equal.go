@@ -0,0 +1,34 @@
+package dyad
+
+import "reflect"
+
+// Equal returns true if a and b are deeply equal.
+//
+// It traverses values in the same way as Clone(), such that Equal(v, Clone(v))
+// is true when both use the same options, unless the clone is changed by a
+// function registered using WithTransform(). Transforms and the masks used by
+// Redact() are not applied when comparing values. Unexported fields and
+// channels are compared according to the same strategies used by Clone(), and
+// it panics under the same circumstances.
+//
+// time.Time values are compared using their Equal() method. Pointers, slices
+// and maps that refer back to themselves are supported.
+func Equal[T any](a, b T, options ...Option) bool {
+	eq, err := equal(a, b, options)
+	if err != nil {
+		panic(err)
+	}
+
+	return eq
+}
+
+func equal[T any](a, b T, options []Option) (bool, error) {
+	aV := reflect.ValueOf(&a).Elem()
+	bV := reflect.ValueOf(&b).Elem()
+
+	return equalValues(
+		newCloneContext(options).WithRoot(aV.Type()),
+		aV,
+		bV,
+	)
+}
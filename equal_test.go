@@ -0,0 +1,184 @@
+package dyad_test
+
+import (
+	"math"
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Equal()", func() {
+	It("returns true for a value and its clone", func() {
+		type Value struct {
+			Ptr       *string
+			Slice     []int
+			Map       map[*string]any
+			Interface any
+			Time      time.Time
+			Float     float64
+		}
+
+		key := "<key>"
+		value := "<value>"
+		src := Value{
+			Ptr:       &value,
+			Slice:     []int{1, 2, 3},
+			Map:       map[*string]any{&key: []string{"<elem>"}},
+			Interface: &value,
+			Time:      time.Now(),
+			Float:     math.NaN(),
+		}
+
+		Expect(dyad.Equal(src, dyad.Clone(src))).To(BeTrue())
+	})
+
+	It("returns false if the values differ", func() {
+		a, b := "<a>", "<b>"
+
+		Expect(dyad.Equal(&a, &b)).To(BeFalse())
+		Expect(dyad.Equal([]int{1}, []int{1, 2})).To(BeFalse())
+		Expect(dyad.Equal([]int{}, nil)).To(BeFalse())
+		Expect(dyad.Equal(map[string]int{"a": 1}, map[string]int{"b": 1})).To(BeFalse())
+		Expect(dyad.Equal[any](1, "1")).To(BeFalse())
+	})
+
+	It("compares the real and imaginary parts of complex values separately", func() {
+		nan := math.NaN()
+
+		Expect(dyad.Equal(complex(nan, 1), complex(nan, 1))).To(BeTrue())
+		Expect(dyad.Equal(complex(nan, 1), complex(2, nan))).To(BeFalse())
+		Expect(dyad.Equal(complex(nan, 1), complex(nan, 2))).To(BeFalse())
+	})
+
+	It("compares time.Time values using their Equal() method", func() {
+		t := time.Now()
+
+		Expect(dyad.Equal(t, t.UTC())).To(BeTrue())
+	})
+
+	It("supports cyclic values", func() {
+		type Node struct {
+			Next *Node
+		}
+
+		a := &Node{}
+		a.Next = a
+
+		b := &Node{}
+		b.Next = b
+
+		Expect(dyad.Equal(a, b)).To(BeTrue())
+	})
+
+	It("supports cyclic values of different lengths", func() {
+		aSlice := []any{nil}
+		aSlice[0] = aSlice
+
+		bSlice := []any{nil, 1}
+		bSlice[0] = bSlice
+
+		Expect(dyad.Equal(aSlice, bSlice)).To(BeFalse())
+
+		aMap := map[string]any{}
+		aMap["self"] = aMap
+
+		bMap := map[string]any{"other": 1}
+		bMap["self"] = bMap
+
+		Expect(dyad.Equal(aMap, bMap)).To(BeFalse())
+	})
+
+	It("compares data that is referred to at multiple paths only once", func() {
+		type Node struct {
+			L, R  *Node
+			Value int
+		}
+
+		// Each chain has 2^64 paths from the root to the leaf, so this test
+		// only completes if shared data is not compared at every path.
+		chain := func(leaf int) *Node {
+			n := &Node{Value: leaf}
+			for i := 0; i < 64; i++ {
+				n = &Node{L: n, R: n}
+			}
+			return n
+		}
+
+		Expect(dyad.Equal(chain(1), chain(1))).To(BeTrue())
+		Expect(dyad.Equal(chain(1), chain(2))).To(BeFalse())
+	})
+
+	When("the values contain unexported fields", func() {
+		type Value struct {
+			Exported   string
+			unexported string
+		}
+
+		It("panics", func() {
+			Expect(func() {
+				dyad.Equal(Value{}, Value{})
+			}).To(PanicWith(MatchError(
+				"dyad_test.Value: struct cannot be cloned due to unexported field (dyad_test.Value.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+			)))
+		})
+
+		It("compares unexported fields when using the CloneUnexportedFields strategy", func() {
+			Expect(dyad.Equal(
+				Value{"<exported>", "<a>"},
+				Value{"<exported>", "<b>"},
+				dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+			)).To(BeFalse())
+		})
+
+		It("ignores unexported fields when using the IgnoreUnexportedFields strategy", func() {
+			Expect(dyad.Equal(
+				Value{"<exported>", "<a>"},
+				Value{"<exported>", "<b>"},
+				dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+			)).To(BeTrue())
+		})
+	})
+
+	When("the values contain channels", func() {
+		It("panics", func() {
+			Expect(func() {
+				dyad.Equal(make(chan int), make(chan int))
+			}).To(PanicWith(MatchError(
+				"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+			)))
+		})
+
+		It("panics if a channel is within an array, as Clone() does", func() {
+			ch := make(chan int)
+			arr := [1]chan int{ch}
+
+			Expect(func() {
+				dyad.Clone(arr)
+			}).To(PanicWith(MatchError(
+				"[1]chan int[0]: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+			)))
+
+			Expect(func() {
+				dyad.Equal(arr, arr)
+			}).To(PanicWith(MatchError(
+				"[1]chan int[0]: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+			)))
+		})
+
+		It("compares channel identity when using the ShareChannels strategy", func() {
+			ch := make(chan int)
+
+			Expect(dyad.Equal(ch, ch, dyad.WithChannelStrategy(dyad.ShareChannels))).To(BeTrue())
+			Expect(dyad.Equal(ch, make(chan int), dyad.WithChannelStrategy(dyad.ShareChannels))).To(BeFalse())
+		})
+
+		It("ignores channels when using the IgnoreChannels strategy", func() {
+			src := make(chan int)
+			dst := dyad.Clone(src, dyad.WithChannelStrategy(dyad.IgnoreChannels))
+
+			Expect(dyad.Equal(src, dst, dyad.WithChannelStrategy(dyad.IgnoreChannels))).To(BeTrue())
+		})
+	})
+})
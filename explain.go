@@ -0,0 +1,249 @@
+package dyad
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Explain returns a plan describing how Clone() clones values of type T using
+// the given options.
+//
+// The plan is a tree with a node for each value within T. Paths within the
+// plan use "[*]" to refer to any element of a slice or map and "[key]" to
+// refer to any key of a map. The contents of interfaces cannot be explained,
+// as their types are only known at runtime.
+func Explain[T any](options ...Option) *Plan {
+	t := typeOf[T]()
+	p := &planner{
+		inProgress: map[reflect.Type]struct{}{},
+	}
+
+	return p.Plan(
+		newCloneContext(options).WithRoot(t),
+		t,
+	)
+}
+
+// A Plan describes how Clone() clones a value at a specific path.
+//
+// Plans are rendered as an indented tree by String(), and may also be encoded
+// as JSON.
+type Plan struct {
+	// Path is the path to the value, in the same format used within error
+	// messages.
+	Path string `json:"path"`
+
+	// Type is the name of the value's type.
+	Type string `json:"type"`
+
+	// Action is the action that Clone() takes when it encounters the value.
+	Action Action `json:"action"`
+
+	// Reason is a human-readable explanation of why the action is taken. It
+	// may be empty.
+	Reason string `json:"reason,omitempty"`
+
+	// Children are the plans for the values nested within this value.
+	Children []*Plan `json:"children,omitempty"`
+
+	// err is the error that occurs when Action is FailAction.
+	err error
+}
+
+// String returns a human-readable representation of the plan.
+func (p *Plan) String() string {
+	w := &strings.Builder{}
+	p.write(w, 0)
+	return w.String()
+}
+
+func (p *Plan) write(w *strings.Builder, depth int) {
+	fmt.Fprintf(
+		w,
+		"%s%s: %s %s",
+		strings.Repeat("  ", depth),
+		p.Path,
+		p.Action,
+		p.Type,
+	)
+
+	if p.Reason != "" {
+		fmt.Fprintf(w, " (%s)", p.Reason)
+	}
+
+	w.WriteByte('\n')
+
+	for _, c := range p.Children {
+		c.write(w, depth+1)
+	}
+}
+
+// walk calls fn for p and each of its descendants.
+func (p *Plan) walk(fn func(*Plan)) {
+	fn(p)
+
+	for _, c := range p.Children {
+		c.walk(fn)
+	}
+}
+
+// Action is an enumeration of the actions that Clone() can take when it
+// encounters a value.
+type Action int
+
+const (
+	// DeepCopyAction is the action taken when a value is cloned by
+	// recursively cloning the values nested within it.
+	DeepCopyAction Action = iota
+
+	// CopyAction is the action taken when a value is copied by assignment,
+	// such as with strings and numeric types.
+	CopyAction
+
+	// ShareAction is the action taken when the original and cloned values
+	// share the same underlying data, such as with time.Time values and
+	// channels when using the ShareChannels strategy.
+	ShareAction
+
+	// IgnoreAction is the action taken when a value is not cloned at all,
+	// leaving the zero-value in its place.
+	IgnoreAction
+
+	// FailAction is the action taken when a value cannot be cloned, causing
+	// Clone() to panic.
+	FailAction
+
+	// TransformAction is the action taken when a value is replaced by the
+	// result of a function registered using WithTransform().
+	TransformAction
+)
+
+var actionNames = map[Action]string{
+	DeepCopyAction:  "deep copy",
+	CopyAction:      "copy",
+	ShareAction:     "share",
+	IgnoreAction:    "ignore",
+	FailAction:      "fail",
+	TransformAction: "transform",
+}
+
+// String returns a human-readable representation of the action.
+func (a Action) String() string {
+	if n, ok := actionNames[a]; ok {
+		return n
+	}
+
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// MarshalText returns a text representation of the action, using hyphens in
+// place of spaces.
+func (a Action) MarshalText() ([]byte, error) {
+	if n, ok := actionNames[a]; ok {
+		return []byte(strings.ReplaceAll(n, " ", "-")), nil
+	}
+
+	return nil, fmt.Errorf("unknown action (%d)", int(a))
+}
+
+// planner builds a plan for cloning a type.
+type planner struct {
+	// inProgress is the set of types that are currently being planned, used
+	// to avoid infinite recursion when planning recursive types.
+	inProgress map[reflect.Type]struct{}
+}
+
+func (p *planner) Plan(ctx cloneContext, t reflect.Type) *Plan {
+	plan := &Plan{
+		Path:   ctx.path.String(),
+		Type:   renderTypeName(t),
+		Action: DeepCopyAction,
+	}
+
+	if _, ok := ctx.options.transforms[t]; ok {
+		plan.Action = TransformAction
+		plan.Reason = "using the function registered by dyad.WithTransform()"
+		return plan
+	}
+
+	if t == timeType {
+		plan.Action = ShareAction
+		plan.Reason = "time.Time values are copied without cloning their location"
+		return plan
+	}
+
+	if _, ok := p.inProgress[t]; ok {
+		plan.Reason = "recursive type, planned above"
+		return plan
+	}
+
+	p.inProgress[t] = struct{}{}
+	defer delete(p.inProgress, t)
+
+	switch t.Kind() {
+	case reflect.Interface:
+		plan.Reason = "cloned according to the dynamic type of the value"
+	case reflect.Ptr:
+		plan.Children = append(plan.Children, p.Plan(ctx, t.Elem()))
+	case reflect.Slice, reflect.Array:
+		plan.Children = append(plan.Children, p.Plan(ctx.WithAnyElem(), t.Elem()))
+	case reflect.Map:
+		plan.Children = append(
+			plan.Children,
+			p.Plan(ctx.WithAnyKey(), t.Key()),
+			p.Plan(ctx.WithAnyElem(), t.Elem()),
+		)
+	case reflect.Struct:
+		p.planStruct(ctx, t, plan)
+	case reflect.Chan:
+		p.planChannel(ctx, plan)
+	default:
+		plan.Action = CopyAction
+	}
+
+	return plan
+}
+
+func (p *planner) planStruct(ctx cloneContext, t reflect.Type, plan *Plan) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldCtx := ctx.WithField(field.Name)
+
+		if ok, err := includeField(ctx, t, field); err != nil {
+			plan.Children = append(plan.Children, &Plan{
+				Path:   fieldCtx.path.String(),
+				Type:   renderTypeName(field.Type),
+				Action: FailAction,
+				Reason: unexportedFieldMessage(t, field),
+				err:    err,
+			})
+			continue
+		} else if !ok {
+			plan.Children = append(plan.Children, &Plan{
+				Path:   fieldCtx.path.String(),
+				Type:   renderTypeName(field.Type),
+				Action: IgnoreAction,
+				Reason: "unexported field, using the IgnoreUnexportedFields strategy",
+			})
+			continue
+		}
+
+		plan.Children = append(plan.Children, p.Plan(fieldCtx, field.Type))
+	}
+}
+
+func (p *planner) planChannel(ctx cloneContext, plan *Plan) {
+	switch ctx.options.channelStrategy {
+	case ShareChannels:
+		plan.Action = ShareAction
+		plan.Reason = "using the ShareChannels strategy"
+	case IgnoreChannels:
+		plan.Action = IgnoreAction
+		plan.Reason = "using the IgnoreChannels strategy"
+	default:
+		plan.Action = FailAction
+		plan.Reason = channelMessage
+		plan.err = channelError(ctx)
+	}
+}
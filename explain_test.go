@@ -0,0 +1,127 @@
+package dyad_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Explain()", func() {
+	It("describes how each value within the type is cloned", func() {
+		type Type struct {
+			Value     string
+			Ptr       *int
+			Map       map[string][]byte
+			Array     [2]*int
+			Interface any
+			Time      time.Time
+		}
+
+		Expect(dyad.Explain[Type]().String()).To(Equal(
+			"dyad_test.Type: deep copy dyad_test.Type\n" +
+				"  dyad_test.Type.Value: copy string\n" +
+				"  dyad_test.Type.Ptr: deep copy *int\n" +
+				"    dyad_test.Type.Ptr: copy int\n" +
+				"  dyad_test.Type.Map: deep copy map[string][]uint8\n" +
+				"    dyad_test.Type.Map[key]: copy string\n" +
+				"    dyad_test.Type.Map[*]: deep copy []uint8\n" +
+				"      dyad_test.Type.Map[*][*]: copy uint8\n" +
+				"  dyad_test.Type.Array: deep copy [2]*int\n" +
+				"    dyad_test.Type.Array[*]: deep copy *int\n" +
+				"      dyad_test.Type.Array[*]: copy int\n" +
+				"  dyad_test.Type.Interface: deep copy any (cloned according to the dynamic type of the value)\n" +
+				"  dyad_test.Type.Time: share time.Time (time.Time values are copied without cloning their location)\n",
+		))
+	})
+
+	It("describes values that cannot be cloned", func() {
+		type Type struct {
+			Channel    chan int
+			unexported string
+		}
+
+		plan := dyad.Explain[Type]()
+
+		Expect(plan.Children).To(HaveLen(2))
+		Expect(plan.Children[0].Action).To(Equal(dyad.FailAction))
+		Expect(plan.Children[0].Reason).To(Equal("channels cannot be cloned, try the dyad.WithChannelStrategy() option"))
+		Expect(plan.Children[1].Action).To(Equal(dyad.FailAction))
+		Expect(plan.Children[1].Reason).To(Equal("struct cannot be cloned due to unexported field (dyad_test.Type.unexported), try the dyad.WithUnexportedFieldStrategy() option"))
+	})
+
+	It("honors the clone options", func() {
+		type Type struct {
+			Channel    chan int
+			unexported string
+		}
+
+		plan := dyad.Explain[Type](
+			dyad.WithChannelStrategy(dyad.ShareChannels),
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)
+
+		Expect(plan.Children).To(HaveLen(2))
+		Expect(plan.Children[0].Action).To(Equal(dyad.ShareAction))
+		Expect(plan.Children[1].Action).To(Equal(dyad.IgnoreAction))
+
+		plan = dyad.Explain[Type](
+			dyad.WithChannelStrategy(dyad.IgnoreChannels),
+			dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+		)
+
+		Expect(plan.Children).To(HaveLen(2))
+		Expect(plan.Children[0].Action).To(Equal(dyad.IgnoreAction))
+		Expect(plan.Children[1].Action).To(Equal(dyad.CopyAction))
+	})
+
+	It("describes values that are transformed", func() {
+		type Type struct {
+			Time time.Time
+		}
+
+		plan := dyad.Explain[Type](
+			dyad.WithTransform(func(p dyad.Path, v time.Time) time.Time {
+				return v
+			}),
+		)
+
+		Expect(plan.Children).To(HaveLen(1))
+		Expect(plan.Children[0].Action).To(Equal(dyad.TransformAction))
+	})
+
+	It("supports recursive types", func() {
+		type Type struct {
+			Next *Type
+		}
+
+		Expect(dyad.Explain[Type]().String()).To(Equal(
+			"dyad_test.Type: deep copy dyad_test.Type\n" +
+				"  dyad_test.Type.Next: deep copy *dyad_test.Type\n" +
+				"    dyad_test.Type.Next: deep copy dyad_test.Type (recursive type, planned above)\n",
+		))
+	})
+
+	It("can be encoded as JSON", func() {
+		type Type struct {
+			Value string
+		}
+
+		data, err := json.Marshal(dyad.Explain[Type]())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"path": "dyad_test.Type",
+			"type": "dyad_test.Type",
+			"action": "deep-copy",
+			"children": [
+				{
+					"path": "dyad_test.Type.Value",
+					"type": "string",
+					"action": "copy"
+				}
+			]
+		}`))
+	})
+})
@@ -0,0 +1,544 @@
+package dyad
+
+import (
+	"encoding"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ToGeneric returns a representation of v that is built only from maps of type
+// map[string]any, slices of type []any and the scalar values within v.
+//
+// It traverses values in the same way as Clone(). Structs are represented as
+// maps of field name to value, and maps are represented using the string
+// representation of their keys. Pointers and interfaces are represented by the
+// values they refer to. time.Time values, channels and functions are retained
+// as-is.
+//
+// Unexported fields and channels are handled according to the same strategies
+// used by Clone(). It returns an error under the same circumstances that
+// Clone() would panic, if v contains a map key that cannot be represented as a
+// string, or if v is cyclic.
+func ToGeneric[T any](v T, options ...Option) (any, error) {
+	root := reflect.ValueOf(&v).Elem()
+	e := &genericEncoder{
+		inProgress: map[reference]struct{}{},
+	}
+
+	return e.Encode(
+		newCloneContext(options).WithRoot(root.Type()),
+		root,
+	)
+}
+
+// FromGeneric returns a value of type T that is built from tree, which is a
+// representation of such a value as returned by ToGeneric().
+//
+// The values within tree are converted to the types used within T. Numeric
+// values may be converted to other numeric types, provided that no information
+// is lost. Values stored in interfaces are cloned as-is, so a struct that is
+// represented as a map is stored in an interface as a map.
+//
+// Unexported fields and channels are handled according to the same strategies
+// used by Clone(). It returns an error under the same circumstances that
+// Clone() would panic, or if tree does not have the structure of a T.
+func FromGeneric[T any](tree any, options ...Option) (v T, err error) {
+	root := reflect.ValueOf(&v).Elem()
+
+	err = decodeGeneric(
+		newCloneContext(options).WithRoot(root.Type()),
+		root,
+		tree,
+	)
+
+	return v, err
+}
+
+// genericEncoder builds the generic representation of a value.
+type genericEncoder struct {
+	// inProgress is the set of references that are currently being encoded,
+	// used to detect cyclic values.
+	inProgress map[reference]struct{}
+}
+
+func (e *genericEncoder) Encode(
+	ctx cloneContext,
+	v reflect.Value,
+) (any, error) {
+	if v.Type() == timeType {
+		return v.Interface(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return e.Encode(ctx.WithType(v.Elem().Type()), v.Elem())
+	case reflect.Ptr:
+		return e.encodeReference(ctx, v, func() (any, error) {
+			return e.Encode(ctx, v.Elem())
+		})
+	case reflect.Slice:
+		return e.encodeReference(ctx, v, func() (any, error) {
+			return e.encodeElements(ctx, v)
+		})
+	case reflect.Array:
+		return e.encodeElements(ctx, v)
+	case reflect.Map:
+		return e.encodeReference(ctx, v, func() (any, error) {
+			return e.encodeMap(ctx, v)
+		})
+	case reflect.Struct:
+		return e.encodeStruct(ctx, v)
+	case reflect.Chan:
+		switch ctx.options.channelStrategy {
+		case ShareChannels:
+			return v.Interface(), nil
+		case IgnoreChannels:
+			return nil, nil
+		default:
+			return nil, channelError(ctx)
+		}
+	default:
+		return v.Interface(), nil
+	}
+}
+
+// encodeReference calls fn to encode the values referred to by v, which must
+// be a pointer, slice or map, unless v is nil.
+func (e *genericEncoder) encodeReference(
+	ctx cloneContext,
+	v reflect.Value,
+	fn func() (any, error),
+) (any, error) {
+	if v.IsNil() {
+		return nil, nil
+	}
+
+	ref := referenceTo(v)
+
+	if _, ok := e.inProgress[ref]; ok {
+		return nil, ctx.Error("cannot convert cyclic value to generic representation")
+	}
+
+	e.inProgress[ref] = struct{}{}
+	defer delete(e.inProgress, ref)
+
+	return fn()
+}
+
+func (e *genericEncoder) encodeElements(
+	ctx cloneContext,
+	v reflect.Value,
+) (any, error) {
+	elems := make([]any, v.Len())
+
+	for i := range elems {
+		elem, err := e.Encode(ctx.WithIndex(i), v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+
+		elems[i] = elem
+	}
+
+	return elems, nil
+}
+
+func (e *genericEncoder) encodeMap(
+	ctx cloneContext,
+	v reflect.Value,
+) (any, error) {
+	m := make(map[string]any, v.Len())
+
+	for _, key := range sortedMapKeys(v) {
+		ctx := ctx.WithKey(key)
+
+		k, err := encodeGenericKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		elem, err := e.Encode(ctx, v.MapIndex(key))
+		if err != nil {
+			return nil, err
+		}
+
+		m[k] = elem
+	}
+
+	return m, nil
+}
+
+func (e *genericEncoder) encodeStruct(
+	ctx cloneContext,
+	v reflect.Value,
+) (any, error) {
+	size := v.NumField()
+	structType := v.Type()
+	m := make(map[string]any, size)
+
+	for i := 0; i < size; i++ {
+		field := structType.Field(i)
+
+		value, ok, err := fieldValue(ctx, v, i)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Chan && ctx.options.channelStrategy == IgnoreChannels {
+			continue
+		}
+
+		elem, err := e.Encode(ctx.WithField(field.Name), value)
+		if err != nil {
+			return nil, err
+		}
+
+		m[field.Name] = elem
+	}
+
+	return m, nil
+}
+
+var (
+	textMarshalerType   = typeOf[encoding.TextMarshaler]()
+	textUnmarshalerType = typeOf[encoding.TextUnmarshaler]()
+)
+
+// encodeGenericKey returns the string representation of the map key k.
+func encodeGenericKey(ctx cloneContext, k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+
+	if k.Type().Implements(textMarshalerType) {
+		text, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", ctx.Error("cannot marshal map key: %s", err)
+		}
+		return string(text), nil
+	}
+
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(k.Float(), 'g', -1, k.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(k.Bool()), nil
+	default:
+		return "", ctx.Error("cannot represent map key of type %s as a string", k.Type())
+	}
+}
+
+// decodeGenericKey parses the string representation of a map key of type t.
+func decodeGenericKey(ctx cloneContext, s string, t reflect.Type) (reflect.Value, error) {
+	k := reflect.New(t).Elem()
+
+	if t.Kind() == reflect.String {
+		k.SetString(s)
+		return k, nil
+	}
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		if err := k.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return reflect.Value{}, ctx.Error("cannot unmarshal map key %q: %s", s, err)
+		}
+		return k, nil
+	}
+
+	var err error
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, t.Bits())
+		k.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, t.Bits())
+		k.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		n, err = strconv.ParseFloat(s, t.Bits())
+		k.SetFloat(n)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		k.SetBool(b)
+	default:
+		return reflect.Value{}, ctx.Error("cannot represent map key of type %s as a string", t)
+	}
+
+	if err != nil {
+		return reflect.Value{}, ctx.Error("cannot parse map key %q as %s", s, t)
+	}
+
+	return k, nil
+}
+
+func decodeGeneric(
+	ctx cloneContext,
+	dst reflect.Value,
+	x any,
+) error {
+	t := dst.Type()
+
+	if x == nil {
+		dst.Set(reflect.Zero(t))
+		return nil
+	}
+
+	if t == timeType {
+		return decodeGenericScalar(ctx, dst, x)
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return setClone(ctx, dst, x)
+	case reflect.Ptr:
+		ptr := reflect.New(t.Elem())
+		if err := decodeGeneric(ctx, ptr.Elem(), x); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	case reflect.Slice:
+		return decodeGenericSlice(ctx, dst, x)
+	case reflect.Array:
+		return decodeGenericArray(ctx, dst, x)
+	case reflect.Map:
+		return decodeGenericMap(ctx, dst, x)
+	case reflect.Struct:
+		return decodeGenericStruct(ctx, dst, x)
+	case reflect.Chan:
+		switch ctx.options.channelStrategy {
+		case ShareChannels:
+			return decodeGenericScalar(ctx, dst, x)
+		case IgnoreChannels:
+			return nil
+		default:
+			return channelError(ctx)
+		}
+	default:
+		return decodeGenericScalar(ctx, dst, x)
+	}
+}
+
+func decodeGenericSlice(
+	ctx cloneContext,
+	dst reflect.Value,
+	x any,
+) error {
+	elems, ok := x.([]any)
+	if !ok {
+		return genericTypeError(ctx, dst, x)
+	}
+
+	slice := reflect.MakeSlice(dst.Type(), len(elems), len(elems))
+
+	for i, elem := range elems {
+		if err := decodeGeneric(ctx.WithIndex(i), slice.Index(i), elem); err != nil {
+			return err
+		}
+	}
+
+	dst.Set(slice)
+
+	return nil
+}
+
+func decodeGenericArray(
+	ctx cloneContext,
+	dst reflect.Value,
+	x any,
+) error {
+	elems, ok := x.([]any)
+	if !ok {
+		return genericTypeError(ctx, dst, x)
+	}
+
+	if len(elems) != dst.Len() {
+		return ctx.Error("cannot use %d elements as %s", len(elems), dst.Type())
+	}
+
+	for i, elem := range elems {
+		if err := decodeGeneric(ctx.WithIndex(i), dst.Index(i), elem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeGenericMap(
+	ctx cloneContext,
+	dst reflect.Value,
+	x any,
+) error {
+	elems, ok := x.(map[string]any)
+	if !ok {
+		return genericTypeError(ctx, dst, x)
+	}
+
+	mapType := dst.Type()
+	m := reflect.MakeMapWithSize(mapType, len(elems))
+
+	for _, k := range sortedGenericKeys(elems) {
+		key, err := decodeGenericKey(ctx, k, mapType.Key())
+		if err != nil {
+			return err
+		}
+
+		elem := reflect.New(mapType.Elem()).Elem()
+		if err := decodeGeneric(ctx.WithKey(key), elem, elems[k]); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(key, elem)
+	}
+
+	dst.Set(m)
+
+	return nil
+}
+
+func decodeGenericStruct(
+	ctx cloneContext,
+	dst reflect.Value,
+	x any,
+) error {
+	fields, ok := x.(map[string]any)
+	if !ok {
+		return genericTypeError(ctx, dst, x)
+	}
+
+	structType := dst.Type()
+
+	for _, name := range sortedGenericKeys(fields) {
+		field, ok := structType.FieldByName(name)
+		if !ok || len(field.Index) != 1 {
+			return ctx.Error("%s has no field named %s", structType, name)
+		}
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		value, ok, err := fieldValue(ctx, dst, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		elem, ok := fields[field.Name]
+		if !ok {
+			continue
+		}
+
+		if err := decodeGeneric(ctx.WithField(field.Name), value, elem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeGenericScalar sets dst to the scalar value x, converting it to the
+// type of dst if it can be done without losing information.
+func decodeGenericScalar(
+	ctx cloneContext,
+	dst reflect.Value,
+	x any,
+) error {
+	src := reflect.ValueOf(x)
+	t := dst.Type()
+
+	if src.Type().AssignableTo(t) {
+		dst.Set(src)
+		return nil
+	}
+
+	if !isLosslessConversion(src, t) {
+		return genericTypeError(ctx, dst, x)
+	}
+
+	dst.Set(src.Convert(t))
+
+	return nil
+}
+
+// isLosslessConversion returns true if v can be converted to t and back to its
+// original type without changing its value.
+func isLosslessConversion(v reflect.Value, t reflect.Type) bool {
+	if v.Kind() != t.Kind() && (!isNumber(v.Kind()) || !isNumber(t.Kind())) {
+		return false
+	}
+
+	if !v.CanConvert(t) {
+		return false
+	}
+
+	if v.Kind() == t.Kind() {
+		return true
+	}
+
+	c := v.Convert(t)
+
+	return isNegative(c) == isNegative(v) &&
+		c.Convert(v.Type()).Interface() == v.Interface()
+}
+
+// isNumber returns true if k is an integer or floating-point kind.
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNegative returns true if v, which must be a number, is less than zero.
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
+
+// genericTypeError returns the error that occurs when x cannot be used as the
+// value of dst.
+func genericTypeError(ctx cloneContext, dst reflect.Value, x any) error {
+	return ctx.Error("cannot use value of type %T as %s", x, dst.Type())
+}
+
+// sortedGenericKeys returns the keys of m in order, such that errors are
+// reported deterministically.
+func sortedGenericKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
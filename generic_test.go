@@ -0,0 +1,288 @@
+package dyad_test
+
+import (
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func ToGeneric()", func() {
+	type Inner struct {
+		Value int
+	}
+
+	type Value struct {
+		Name      string
+		Ptr       *Inner
+		Nil       *Inner
+		Slice     []int
+		Array     [2]bool
+		Map       map[int]string
+		Interface any
+		Time      time.Time
+	}
+
+	It("returns the generic representation of the value", func() {
+		t := time.Now()
+
+		v, err := dyad.ToGeneric(Value{
+			Name:      "<name>",
+			Ptr:       &Inner{1},
+			Slice:     []int{2, 3},
+			Array:     [2]bool{true, false},
+			Map:       map[int]string{4: "<four>"},
+			Interface: Inner{5},
+			Time:      t,
+		})
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(map[string]any{
+			"Name":      "<name>",
+			"Ptr":       map[string]any{"Value": 1},
+			"Nil":       nil,
+			"Slice":     []any{2, 3},
+			"Array":     []any{true, false},
+			"Map":       map[string]any{"4": "<four>"},
+			"Interface": map[string]any{"Value": 5},
+			"Time":      t,
+		}))
+	})
+
+	It("uses the text representation of keys that implement encoding.TextMarshaler", func() {
+		key := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		v, err := dyad.ToGeneric(map[time.Time]int{key: 1})
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(map[string]any{"2024-01-02T03:04:05Z": 1}))
+	})
+
+	It("returns an error if a map key cannot be represented as a string", func() {
+		_, err := dyad.ToGeneric(map[Inner]int{{1}: 1})
+
+		Expect(err).To(MatchError(
+			"map[dyad_test.Inner]int[dyad_test.Inner{Value:1}]: cannot represent map key of type dyad_test.Inner as a string",
+		))
+	})
+
+	It("returns an error if the value is cyclic", func() {
+		type Node struct {
+			Next *Node
+		}
+
+		n := &Node{}
+		n.Next = n
+
+		_, err := dyad.ToGeneric(n)
+
+		Expect(err).To(MatchError(
+			"*dyad_test.Node.Next: cannot convert cyclic value to generic representation",
+		))
+	})
+
+	It("honours the unexported field strategy", func() {
+		type Private struct {
+			Public  int
+			private int
+		}
+
+		_, err := dyad.ToGeneric(Private{1, 2})
+		Expect(err).To(MatchError(
+			"dyad_test.Private: struct cannot be cloned due to unexported field (dyad_test.Private.private), try the dyad.WithUnexportedFieldStrategy() option",
+		))
+
+		v, err := dyad.ToGeneric(
+			Private{1, 2},
+			dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(map[string]any{"Public": 1, "private": 2}))
+
+		v, err = dyad.ToGeneric(
+			Private{1, 2},
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(map[string]any{"Public": 1}))
+	})
+
+	It("honours the channel strategy", func() {
+		type Channel struct {
+			C chan int
+		}
+
+		ch := make(chan int)
+
+		_, err := dyad.ToGeneric(Channel{ch})
+		Expect(err).To(MatchError(
+			"dyad_test.Channel.C: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+
+		v, err := dyad.ToGeneric(
+			Channel{ch},
+			dyad.WithChannelStrategy(dyad.ShareChannels),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(map[string]any{"C": ch}))
+
+		v, err = dyad.ToGeneric(
+			Channel{ch},
+			dyad.WithChannelStrategy(dyad.IgnoreChannels),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(map[string]any{}))
+	})
+})
+
+var _ = Describe("func FromGeneric()", func() {
+	type Inner struct {
+		Value int
+	}
+
+	type Value struct {
+		Name      string
+		Ptr       *Inner
+		Nil       *Inner
+		Slice     []int
+		Array     [2]bool
+		Map       map[int]string
+		Interface any
+		Time      time.Time
+	}
+
+	It("rebuilds a value from its generic representation", func() {
+		src := Value{
+			Name:      "<name>",
+			Ptr:       &Inner{1},
+			Slice:     []int{2, 3},
+			Array:     [2]bool{true, false},
+			Map:       map[int]string{4: "<four>"},
+			Interface: 5,
+			Time:      time.Now(),
+		}
+
+		tree, err := dyad.ToGeneric(src)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		dst, err := dyad.FromGeneric[Value](tree)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(src))
+	})
+
+	It("converts numeric values without losing information", func() {
+		type Numbers struct {
+			Int   int8
+			Uint  uint
+			Float float32
+		}
+
+		dst, err := dyad.FromGeneric[Numbers](map[string]any{
+			"Int":   float64(100),
+			"Uint":  int(200),
+			"Float": int(300),
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(dst).To(Equal(Numbers{100, 200, 300}))
+	})
+
+	DescribeTable(
+		"it returns an error if the tree does not have the structure of the type",
+		func(tree any, expect string) {
+			_, err := dyad.FromGeneric[Value](tree)
+			Expect(err).To(MatchError(expect))
+		},
+		Entry(
+			"not a map",
+			[]any{},
+			"dyad_test.Value: cannot use value of type []interface {} as dyad_test.Value",
+		),
+		Entry(
+			"unknown field",
+			map[string]any{"Unknown": 1},
+			"dyad_test.Value: dyad_test.Value has no field named Unknown",
+		),
+		Entry(
+			"wrong scalar type",
+			map[string]any{"Name": 1},
+			"dyad_test.Value.Name: cannot use value of type int as string",
+		),
+		Entry(
+			"lossy numeric conversion",
+			map[string]any{"Slice": []any{1.5}},
+			"dyad_test.Value.Slice[0]: cannot use value of type float64 as int",
+		),
+		Entry(
+			"overflowing numeric conversion",
+			map[string]any{"Ptr": map[string]any{"Value": uint64(1 << 63)}},
+			"dyad_test.Value.Ptr.Value: cannot use value of type uint64 as int",
+		),
+		Entry(
+			"wrong array length",
+			map[string]any{"Array": []any{true}},
+			"dyad_test.Value.Array: cannot use 1 elements as [2]bool",
+		),
+		Entry(
+			"invalid map key",
+			map[string]any{"Map": map[string]any{"x": ""}},
+			`dyad_test.Value.Map: cannot parse map key "x" as int`,
+		),
+	)
+
+	It("honours the unexported field strategy", func() {
+		type Private struct {
+			Public  int
+			private int
+		}
+
+		tree := map[string]any{"Public": 1, "private": 2}
+
+		_, err := dyad.FromGeneric[Private](tree)
+		Expect(err).To(MatchError(
+			"dyad_test.Private: struct cannot be cloned due to unexported field (dyad_test.Private.private), try the dyad.WithUnexportedFieldStrategy() option",
+		))
+
+		v, err := dyad.FromGeneric[Private](
+			tree,
+			dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(Private{1, 2}))
+
+		v, err = dyad.FromGeneric[Private](
+			tree,
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(Private{1, 0}))
+	})
+
+	It("honours the channel strategy", func() {
+		type Channel struct {
+			C chan int
+		}
+
+		ch := make(chan int)
+		tree := map[string]any{"C": ch}
+
+		_, err := dyad.FromGeneric[Channel](tree)
+		Expect(err).To(MatchError(
+			"dyad_test.Channel.C: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+
+		v, err := dyad.FromGeneric[Channel](
+			tree,
+			dyad.WithChannelStrategy(dyad.ShareChannels),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v.C).To(Equal(ch))
+
+		v, err = dyad.FromGeneric[Channel](
+			tree,
+			dyad.WithChannelStrategy(dyad.IgnoreChannels),
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v.C).To(BeNil())
+	})
+})
@@ -0,0 +1,377 @@
+package dyad
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+// Hash returns a hash of v that is consistent with Equal().
+//
+// Values that are equal according to Equal(), given the same options, always
+// produce the same hash. It traverses values in the same way as Clone(), such
+// that ignored unexported fields and channels do not contribute to the hash.
+// The order of map elements does not affect the hash.
+//
+// Values nested more than 64 pointers, slices or maps deep do not contribute
+// to the hash. This allows cyclic values to be hashed, and ensures values
+// that refer to the same data via different paths are hashed efficiently.
+//
+// It panics under the same circumstances as Clone().
+func Hash[T any](v T, options ...Option) uint64 {
+	root := reflect.ValueOf(&v).Elem()
+	h := &hasher{
+		memo: map[hashKey]uint64{},
+	}
+
+	s := newHashState()
+
+	if err := h.Hash(
+		newCloneContext(options).WithRoot(root.Type()),
+		root,
+		0,
+		s,
+	); err != nil {
+		panic(err)
+	}
+
+	return s.Sum64()
+}
+
+// maxHashDepth is the maximum number of pointers, slices and maps that are
+// followed when hashing a value.
+//
+// The limit applies to every value equally, regardless of the data it refers
+// to. This ensures that cyclic values that are equal, but have cycles of
+// different lengths, still produce the same hash.
+const maxHashDepth = 64
+
+// hasher computes the hash of a value.
+type hasher struct {
+	// memo is the hash of each reference that has already been hashed at a
+	// specific depth.
+	memo map[hashKey]uint64
+}
+
+// hashKey identifies the hash of the data referred to by a pointer, slice or
+// map at a specific depth.
+type hashKey struct {
+	ref   reference
+	len   int
+	depth int
+}
+
+// Hash writes the hash of v to s.
+func (h *hasher) Hash(
+	ctx cloneContext,
+	v reflect.Value,
+	depth int,
+	s *hashState,
+) error {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		s.WriteUint64(uint64(t.Unix()))
+		s.WriteUint64(uint64(t.Nanosecond()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return h.hashInterface(ctx, v, depth, s)
+	case reflect.Ptr:
+		return h.hashReference(ctx, v, depth, s, func(depth int, s *hashState) error {
+			return h.Hash(ctx, v.Elem(), depth, s)
+		})
+	case reflect.Slice:
+		return h.hashReference(ctx, v, depth, s, func(depth int, s *hashState) error {
+			return h.hashElements(ctx, v, depth, s)
+		})
+	case reflect.Array:
+		return h.hashElements(ctx, v, depth, s)
+	case reflect.Map:
+		return h.hashReference(ctx, v, depth, s, func(depth int, s *hashState) error {
+			return h.hashMap(ctx, v, depth, s)
+		})
+	case reflect.Struct:
+		return h.hashStruct(ctx, v, depth, s)
+	case reflect.Chan:
+		switch ctx.options.channelStrategy {
+		case ShareChannels:
+			s.WriteUint64(uint64(v.Pointer()))
+		case IgnoreChannels:
+		default:
+			return channelError(ctx)
+		}
+	case reflect.Func, reflect.UnsafePointer:
+		s.WriteUint64(uint64(v.Pointer()))
+	default:
+		hashBasic(v, s)
+	}
+
+	return nil
+}
+
+func (h *hasher) hashInterface(
+	ctx cloneContext,
+	v reflect.Value,
+	depth int,
+	s *hashState,
+) error {
+	if v.IsNil() {
+		s.WriteUint64(0)
+		return nil
+	}
+
+	elem := v.Elem()
+
+	s.WriteUint64(1)
+	s.WriteString(elem.Type().String())
+
+	return h.Hash(ctx.WithType(elem.Type()), elem, depth, s)
+}
+
+// hashReference writes the hash of the data referred to by v, which must be a
+// pointer, slice or map, to s.
+//
+// fn writes the hash of the data to the given hash state, at the given depth.
+// Hashes are memoized, such that data that is referred to multiple times is
+// only hashed once at each depth.
+func (h *hasher) hashReference(
+	ctx cloneContext,
+	v reflect.Value,
+	depth int,
+	s *hashState,
+	fn func(int, *hashState) error,
+) error {
+	if v.IsNil() {
+		s.WriteUint64(0)
+		return nil
+	}
+
+	s.WriteUint64(1)
+
+	if depth == maxHashDepth {
+		return nil
+	}
+
+	k := hashKey{ref: referenceTo(v), depth: depth}
+	if v.Kind() != reflect.Ptr {
+		k.len = v.Len()
+	}
+
+	x, ok := h.memo[k]
+
+	if !ok {
+		sub := newHashState()
+		if err := fn(depth+1, sub); err != nil {
+			return err
+		}
+
+		x = sub.Sum64()
+		h.memo[k] = x
+	}
+
+	s.WriteUint64(x)
+
+	return nil
+}
+
+func (h *hasher) hashElements(
+	ctx cloneContext,
+	v reflect.Value,
+	depth int,
+	s *hashState,
+) error {
+	s.WriteUint64(uint64(v.Len()))
+
+	// Elements of basic types are hashed directly, as they can not produce an
+	// error and therefore do not require a path.
+	if isBasic(v.Type().Elem().Kind()) {
+		for i := 0; i < v.Len(); i++ {
+			hashBasic(v.Index(i), s)
+		}
+		return nil
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := h.Hash(
+			ctx.WithIndex(i),
+			v.Index(i),
+			depth,
+			s,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *hasher) hashMap(
+	ctx cloneContext,
+	v reflect.Value,
+	depth int,
+	s *hashState,
+) error {
+	// The hashes of the elements are summed so that the order in which they
+	// are visited does not affect the result.
+	var sum uint64
+
+	for _, key := range v.MapKeys() {
+		ctx := ctx.WithKey(key)
+		elem := newHashState()
+
+		if err := h.Hash(ctx, key, depth, elem); err != nil {
+			return err
+		}
+
+		if err := h.Hash(ctx, v.MapIndex(key), depth, elem); err != nil {
+			return err
+		}
+
+		sum += elem.Sum64()
+	}
+
+	s.WriteUint64(uint64(v.Len()))
+	s.WriteUint64(sum)
+
+	return nil
+}
+
+func (h *hasher) hashStruct(
+	ctx cloneContext,
+	v reflect.Value,
+	depth int,
+	s *hashState,
+) error {
+	size := v.NumField()
+	structType := v.Type()
+
+	for i := 0; i < size; i++ {
+		field := structType.Field(i)
+
+		value, ok, err := fieldValue(ctx, v, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if isBasic(field.Type.Kind()) {
+			hashBasic(value, s)
+			continue
+		}
+
+		if err := h.Hash(
+			ctx.WithField(field.Name),
+			value,
+			depth,
+			s,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hashBasic writes the hash of a value of a basic type to s.
+//
+// Floating-point values, including each part of a complex value, are normalized
+// so that values that are equal according to equalBasic() produce the same
+// hash.
+func hashBasic(v reflect.Value, s *hashState) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			s.WriteUint64(1)
+		} else {
+			s.WriteUint64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.WriteUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s.WriteUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		s.WriteUint64(floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		s.WriteUint64(floatBits(real(c)))
+		s.WriteUint64(floatBits(imag(c)))
+	default: // reflect.String
+		s.WriteString(v.String())
+	}
+}
+
+// isBasic returns true if values of kind k are hashed by hashBasic().
+func isBasic(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128,
+		reflect.String:
+		return true
+	default:
+		return false
+	}
+}
+
+// floatBits returns the bits of f, with all NaN values and both zero values
+// normalized.
+func floatBits(f float64) uint64 {
+	switch {
+	case f != f:
+		return math.Float64bits(math.NaN())
+	case f == 0:
+		return 0
+	default:
+		return math.Float64bits(f)
+	}
+}
+
+// hashState is the state of a 64-bit FNV-1a hash that is being computed.
+//
+// It is equivalent to the hash returned by fnv.New64a(), but writes integers
+// and strings directly, without allocating.
+type hashState struct {
+	sum uint64
+}
+
+// FNV-1a parameters, as per hash/fnv.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// newHashState returns a new, empty hash state.
+func newHashState() *hashState {
+	return &hashState{fnvOffset64}
+}
+
+// WriteUint64 writes x to the hash, in little-endian byte order.
+func (s *hashState) WriteUint64(x uint64) {
+	for i := 0; i < 8; i++ {
+		s.writeByte(byte(x >> (8 * i)))
+	}
+}
+
+// WriteString writes str to the hash, prefixed by its length.
+func (s *hashState) WriteString(str string) {
+	s.WriteUint64(uint64(len(str)))
+	for i := 0; i < len(str); i++ {
+		s.writeByte(str[i])
+	}
+}
+
+// Sum64 returns the hash of the data written so far.
+func (s *hashState) Sum64() uint64 {
+	return s.sum
+}
+
+func (s *hashState) writeByte(b byte) {
+	s.sum ^= uint64(b)
+	s.sum *= fnvPrime64
+}
@@ -0,0 +1,168 @@
+package dyad_test
+
+import (
+	"math"
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Hash()", func() {
+	type Value struct {
+		Name      string
+		Ptr       *int
+		Slice     []string
+		Map       map[string]int
+		Interface any
+		Time      time.Time
+	}
+
+	newValue := func() Value {
+		n := 123
+		return Value{
+			Name:      "<name>",
+			Ptr:       &n,
+			Slice:     []string{"<a>", "<b>"},
+			Map:       map[string]int{"<a>": 1, "<b>": 2, "<c>": 3},
+			Interface: 1.5,
+			Time:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+	}
+
+	It("returns the same hash for equal values", func() {
+		a := newValue()
+		b := dyad.Clone(a)
+		b.Time = b.Time.In(time.FixedZone("<zone>", 3600))
+
+		Expect(dyad.Equal(a, b)).To(BeTrue())
+		Expect(dyad.Hash(a)).To(Equal(dyad.Hash(b)))
+	})
+
+	It("returns a different hash for values that are not equal", func() {
+		a := newValue()
+
+		mutations := []func(*Value){
+			func(v *Value) { v.Name = "<other>" },
+			func(v *Value) { *v.Ptr = 456 },
+			func(v *Value) { v.Ptr = nil },
+			func(v *Value) { v.Slice = v.Slice[:1] },
+			func(v *Value) { v.Slice = []string{"<b>", "<a>"} },
+			func(v *Value) { v.Map["<a>"] = 100 },
+			func(v *Value) { v.Interface = float32(1.5) },
+			func(v *Value) { v.Time = v.Time.Add(1) },
+		}
+
+		for _, mutate := range mutations {
+			b := dyad.Clone(a)
+			mutate(&b)
+
+			Expect(dyad.Equal(a, b)).To(BeFalse())
+			Expect(dyad.Hash(a)).NotTo(Equal(dyad.Hash(b)))
+		}
+	})
+
+	It("distinguishes between nil and empty values", func() {
+		Expect(dyad.Hash([]int(nil))).NotTo(Equal(dyad.Hash([]int{})))
+		Expect(dyad.Hash(map[int]int(nil))).NotTo(Equal(dyad.Hash(map[int]int{})))
+	})
+
+	It("normalizes floating-point values in the same way as Equal()", func() {
+		Expect(dyad.Hash(math.NaN())).To(Equal(dyad.Hash(-math.NaN())))
+		Expect(dyad.Hash(math.Copysign(0, -1))).To(Equal(dyad.Hash(0.0)))
+	})
+
+	It("hashes complex values consistently with Equal()", func() {
+		nan := math.NaN()
+
+		values := []complex128{
+			complex(nan, 1),
+			complex(-nan, 1),
+			complex(nan, 2),
+			complex(2, nan),
+			complex(math.Copysign(0, -1), 1),
+			complex(0, 1),
+		}
+
+		for _, a := range values {
+			for _, b := range values {
+				if dyad.Equal(a, b) {
+					Expect(dyad.Hash(a)).To(Equal(dyad.Hash(b)), "%v and %v", a, b)
+				} else {
+					Expect(dyad.Hash(a)).NotTo(Equal(dyad.Hash(b)), "%v and %v", a, b)
+				}
+			}
+		}
+	})
+
+	It("returns the same hash regardless of whether pointers are shared", func() {
+		type Pair struct {
+			A, B *int
+		}
+
+		x, y := 1, 1
+
+		Expect(dyad.Hash(Pair{&x, &x})).To(Equal(dyad.Hash(Pair{&x, &y})))
+	})
+
+	It("supports cyclic values", func() {
+		type Node struct {
+			Value int
+			Next  *Node
+		}
+
+		a := &Node{Value: 1}
+		a.Next = a
+
+		b1 := &Node{Value: 1}
+		b2 := &Node{Value: 1, Next: b1}
+		b1.Next = b2
+
+		Expect(dyad.Equal(a, b1)).To(BeTrue())
+		Expect(dyad.Hash(a)).To(Equal(dyad.Hash(b1)))
+
+		c := &Node{Value: 2}
+		c.Next = c
+
+		Expect(dyad.Hash(a)).NotTo(Equal(dyad.Hash(c)))
+	})
+
+	It("does not include ignored unexported fields", func() {
+		type Private struct {
+			Public  int
+			private int
+		}
+
+		option := dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields)
+
+		Expect(dyad.Hash(Private{1, 2}, option)).To(Equal(dyad.Hash(Private{1, 3}, option)))
+	})
+
+	It("includes unexported fields when using the CloneUnexportedFields strategy", func() {
+		type Private struct {
+			Public  int
+			private int
+		}
+
+		option := dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields)
+
+		Expect(dyad.Hash(Private{1, 2}, option)).NotTo(Equal(dyad.Hash(Private{1, 3}, option)))
+	})
+
+	It("panics if a channel is within an array, as Clone() does", func() {
+		Expect(func() {
+			dyad.Hash([1]chan int{make(chan int)})
+		}).To(PanicWith(MatchError(
+			"[1]chan int[0]: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		)))
+	})
+
+	It("panics if the value cannot be cloned", func() {
+		Expect(func() {
+			dyad.Hash(make(chan int))
+		}).To(PanicWith(MatchError(
+			"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		)))
+	})
+})
@@ -0,0 +1,68 @@
+package dyad_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func WithLogger()", func() {
+	It("logs each decision at the debug level", func() {
+		type Value struct {
+			Channel    chan int
+			Time       time.Time
+			Name       string
+			unexported string
+		}
+
+		buf := &bytes.Buffer{}
+		logger := slog.New(
+			slog.NewTextHandler(
+				buf,
+				&slog.HandlerOptions{
+					Level: slog.LevelDebug,
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if a.Key == slog.TimeKey {
+							return slog.Attr{}
+						}
+						return a
+					},
+				},
+			),
+		)
+
+		dyad.Clone(
+			Value{},
+			dyad.WithLogger(logger),
+			dyad.WithChannelStrategy(dyad.ShareChannels),
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+			dyad.WithTransform(func(p dyad.Path, v string) string {
+				return v
+			}),
+		)
+
+		Expect(strings.Split(strings.TrimSpace(buf.String()), "\n")).To(Equal([]string{
+			`level=DEBUG msg="channel shared" path=dyad_test.Value.Channel type="chan int"`,
+			`level=DEBUG msg="time.Time shared" path=dyad_test.Value.Time type=time.Time`,
+			`level=DEBUG msg="transform applied" path=dyad_test.Value.Name type=string`,
+			`level=DEBUG msg="unexported field ignored" path=dyad_test.Value.unexported type=string`,
+		}))
+	})
+
+	It("does not log anything if the debug level is disabled", func() {
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(buf, nil))
+
+		dyad.Clone(
+			time.Now(),
+			dyad.WithLogger(logger),
+		)
+
+		Expect(buf.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,253 @@
+package dyad
+
+import "reflect"
+
+// Merge recursively merges src into the value pointed to by dst.
+//
+// Maps are merged key by key, and structs are merged field by field. Slices are
+// merged according to the strategy set by WithSliceMergeStrategy(). Any other
+// value from src replaces the corresponding value in dst, unless it is the
+// zero-value. Pointers and interfaces are merged by merging the values they
+// refer to, unless dst is nil or contains a value of a different type.
+//
+// Every value taken from src is cloned, such that dst never shares data with
+// src. It panics under the same circumstances as Clone().
+func Merge[T any](dst *T, src T, options ...Option) {
+	if err := merge(dst, src, options); err != nil {
+		panic(err)
+	}
+}
+
+func merge[T any](dst *T, src T, options []Option) error {
+	srcV := reflect.ValueOf(&src).Elem()
+	dstV := reflect.ValueOf(dst).Elem()
+
+	return mergeInto(
+		newCloneContext(options).WithRoot(srcV.Type()),
+		srcV,
+		dstV,
+	)
+}
+
+func mergeInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	switch src.Type() {
+	case timeType:
+		return mergeZeroableInto(ctx, src, dst)
+	}
+
+	switch src.Kind() {
+	case reflect.Interface:
+		return mergeInterfaceInto(ctx, src, dst)
+	case reflect.Ptr:
+		return mergePtrInto(ctx, src, dst)
+	case reflect.Slice:
+		return mergeSliceInto(ctx, src, dst)
+	case reflect.Map:
+		return mergeMapInto(ctx, src, dst)
+	case reflect.Struct:
+		return mergeStructInto(ctx, src, dst)
+	default:
+		return mergeZeroableInto(ctx, src, dst)
+	}
+}
+
+// mergeZeroableInto replaces dst with a clone of src, unless src is the
+// zero-value.
+func mergeZeroableInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsZero() {
+		return nil
+	}
+
+	return cloneInto(ctx, src, dst)
+}
+
+func mergeInterfaceInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	if dst.IsNil() || dst.Elem().Type() != src.Elem().Type() {
+		return cloneInto(ctx, src, dst)
+	}
+
+	srcElem := src.Elem()
+	dstElem := reflect.New(srcElem.Type()).Elem()
+	dstElem.Set(dst.Elem())
+
+	if err := mergeInto(
+		ctx.WithType(srcElem.Type()),
+		srcElem,
+		dstElem,
+	); err != nil {
+		return err
+	}
+
+	dst.Set(dstElem)
+
+	return nil
+}
+
+func mergePtrInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsNil() || src.Pointer() == dst.Pointer() {
+		return nil
+	}
+
+	if dst.IsNil() {
+		return cloneInto(ctx, src, dst)
+	}
+
+	return mergeInto(ctx, src.Elem(), dst.Elem())
+}
+
+func mergeSliceInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	if dst.IsNil() {
+		return cloneInto(ctx, src, dst)
+	}
+
+	switch ctx.options.sliceMergeStrategy {
+	case AppendSlices:
+		return appendSliceInto(ctx, src, dst, 0)
+	case MergeSlicesByIndex:
+		size := min(src.Len(), dst.Len())
+
+		for i := 0; i < size; i++ {
+			if err := mergeInto(
+				ctx.WithIndex(i),
+				src.Index(i),
+				dst.Index(i),
+			); err != nil {
+				return err
+			}
+		}
+
+		return appendSliceInto(ctx, src, dst, size)
+	default:
+		return cloneInto(ctx, src, dst)
+	}
+}
+
+// appendSliceInto appends clones of the elements of src, starting at the given
+// offset, to dst.
+func appendSliceInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+	offset int,
+) error {
+	size := src.Len()
+	elemType := src.Type().Elem()
+
+	for i := offset; i < size; i++ {
+		elem := reflect.New(elemType).Elem()
+
+		if err := cloneInto(
+			ctx.WithIndex(i),
+			src.Index(i),
+			elem,
+		); err != nil {
+			return err
+		}
+
+		dst.Set(reflect.Append(dst, elem))
+	}
+
+	return nil
+}
+
+func mergeMapInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	if src.IsNil() || src.Pointer() == dst.Pointer() {
+		return nil
+	}
+
+	if dst.IsNil() {
+		return cloneInto(ctx, src, dst)
+	}
+
+	mapType := src.Type()
+	keyType := mapType.Key()
+	elemType := mapType.Elem()
+
+	for _, srcKey := range src.MapKeys() {
+		ctx := ctx.WithKey(srcKey)
+		srcElem := src.MapIndex(srcKey)
+
+		dstElem := reflect.New(elemType).Elem()
+
+		if existing := dst.MapIndex(srcKey); existing.IsValid() {
+			dstElem.Set(existing)
+
+			if err := mergeInto(ctx, srcElem, dstElem); err != nil {
+				return err
+			}
+
+			dst.SetMapIndex(srcKey, dstElem)
+			continue
+		}
+
+		dstKey := reflect.New(keyType).Elem()
+		if err := cloneInto(ctx, srcKey, dstKey); err != nil {
+			return err
+		}
+
+		if err := cloneInto(ctx, srcElem, dstElem); err != nil {
+			return err
+		}
+
+		dst.SetMapIndex(dstKey, dstElem)
+	}
+
+	return nil
+}
+
+func mergeStructInto(
+	ctx cloneContext,
+	src, dst reflect.Value,
+) error {
+	size := src.NumField()
+	srcType := src.Type()
+
+	for i := 0; i < size; i++ {
+		field := srcType.Field(i)
+
+		srcField, ok, err := fieldValue(ctx, src, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		dstField, _, _ := fieldValue(ctx, dst, i)
+
+		if err := mergeInto(
+			ctx.WithField(field.Name),
+			srcField,
+			dstField,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
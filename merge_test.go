@@ -0,0 +1,104 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Merge()", func() {
+	type Config struct {
+		Name    string
+		Port    int
+		Tags    []string
+		Limits  map[string]int
+		Nested  *Config
+		Dynamic any
+	}
+
+	It("overlays non-zero values from the source", func() {
+		dst := Config{
+			Name: "<default>",
+			Port: 8080,
+		}
+
+		dyad.Merge(&dst, Config{Port: 9090})
+
+		Expect(dst).To(Equal(Config{
+			Name: "<default>",
+			Port: 9090,
+		}))
+	})
+
+	It("merges maps key by key", func() {
+		dst := Config{
+			Limits: map[string]int{"a": 1, "b": 2},
+		}
+
+		dyad.Merge(&dst, Config{
+			Limits: map[string]int{"b": 22, "c": 3},
+		})
+
+		Expect(dst.Limits).To(Equal(map[string]int{"a": 1, "b": 22, "c": 3}))
+	})
+
+	It("merges the values referred to by pointers and interfaces", func() {
+		dst := Config{
+			Nested:  &Config{Name: "<default>", Port: 8080},
+			Dynamic: Config{Name: "<default>", Port: 8080},
+		}
+
+		dyad.Merge(&dst, Config{
+			Nested:  &Config{Port: 9090},
+			Dynamic: Config{Port: 9090},
+		})
+
+		Expect(dst).To(Equal(Config{
+			Nested:  &Config{Name: "<default>", Port: 9090},
+			Dynamic: Config{Name: "<default>", Port: 9090},
+		}))
+	})
+
+	It("never shares data with the source", func() {
+		var dst Config
+		src := Config{
+			Tags:   []string{"<tag>"},
+			Limits: map[string]int{"a": 1},
+			Nested: &Config{},
+		}
+
+		dyad.Merge(&dst, src)
+
+		Expect(dst).To(Equal(src))
+		Expect(dst.Nested).ToNot(BeIdenticalTo(src.Nested))
+
+		src.Tags[0] = "<changed>"
+		src.Limits["a"] = 2
+		Expect(dst).ToNot(Equal(src))
+	})
+
+	DescribeTable(
+		"it merges slices according to the slice merge strategy",
+		func(s dyad.SliceMergeStrategy, expect []string) {
+			dst := []string{"<a>", "<b>", "<c>"}
+			dyad.Merge(&dst, []string{"<x>", ""}, dyad.WithSliceMergeStrategy(s))
+			Expect(dst).To(Equal(expect))
+		},
+		Entry("ReplaceSlices", dyad.ReplaceSlices, []string{"<x>", ""}),
+		Entry("AppendSlices", dyad.AppendSlices, []string{"<a>", "<b>", "<c>", "<x>", ""}),
+		Entry("MergeSlicesByIndex", dyad.MergeSlicesByIndex, []string{"<x>", "<b>", "<c>"}),
+	)
+
+	It("panics if the source cannot be cloned", func() {
+		type Value struct {
+			unexported string
+		}
+
+		Expect(func() {
+			var dst Value
+			dyad.Merge(&dst, Value{})
+		}).To(PanicWith(MatchError(
+			"dyad_test.Value: struct cannot be cloned due to unexported field (dyad_test.Value.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+		)))
+	})
+})
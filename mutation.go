@@ -0,0 +1,17 @@
+package dyad
+
+// DetectMutation calls fn and returns the differences between the value
+// pointed to by v before and after fn is called.
+//
+// It takes a deep copy of *v before calling fn, and compares it to *v once fn
+// returns, such that changes made to any value reachable from v are detected,
+// not just changes to *v itself. The differences are returned in the same
+// form as Diff(), with the value before fn is called as the first argument. It
+// returns an empty slice if fn did not modify v.
+//
+// It panics under the same circumstances as Clone().
+func DetectMutation[T any](v *T, fn func(), options ...Option) []Difference {
+	before := Clone(*v, options...)
+	fn()
+	return Diff(before, *v, options...)
+}
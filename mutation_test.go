@@ -0,0 +1,88 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func DetectMutation()", func() {
+	type Message struct {
+		ID     string
+		Tags   []string
+		Attrs  map[string]string
+		Parent *Message
+	}
+
+	var message Message
+
+	BeforeEach(func() {
+		message = Message{
+			ID:     "<id>",
+			Tags:   []string{"<a>", "<b>"},
+			Attrs:  map[string]string{"<key>": "<value>"},
+			Parent: &Message{ID: "<parent>"},
+		}
+	})
+
+	It("returns an empty slice if the value is not modified", func() {
+		diff := dyad.DetectMutation(&message, func() {
+			_ = message.Parent.ID
+		})
+
+		Expect(diff).To(BeEmpty())
+	})
+
+	It("returns the paths of values that are modified", func() {
+		handle := func(m Message) {
+			m.ID = "<changed>"      // not a mutation, m is a copy
+			m.Tags[0] = "<changed>" // mutates the shared backing array
+			m.Attrs["<new>"] = "<new>"
+			m.Parent.ID = "<changed>"
+		}
+
+		diff := dyad.DetectMutation(&message, func() {
+			handle(message)
+		})
+
+		var paths []string
+		for _, d := range diff {
+			paths = append(paths, d.String())
+		}
+
+		Expect(paths).To(Equal([]string{
+			`dyad_test.Message.Tags[0]: modified "<a>" -> "<changed>"`,
+			`dyad_test.Message.Attrs["<new>"]: added "<new>"`,
+			`dyad_test.Message.Parent.ID: modified "<parent>" -> "<changed>"`,
+		}))
+	})
+
+	It("detects modifications to values referred to by array elements", func() {
+		type Value struct {
+			Array [1]*int
+		}
+
+		v := Value{[1]*int{new(int)}}
+
+		diff := dyad.DetectMutation(&v, func() {
+			*v.Array[0] = 99
+		})
+
+		Expect(diff).To(HaveLen(1))
+		Expect(diff[0].String()).To(Equal("dyad_test.Value.Array[0]: modified 0 -> 99"))
+	})
+
+	It("panics if the value cannot be cloned", func() {
+		type Private struct {
+			value int
+		}
+
+		v := Private{}
+
+		Expect(func() {
+			dyad.DetectMutation(&v, func() {})
+		}).To(PanicWith(MatchError(
+			"dyad_test.Private: struct cannot be cloned due to unexported field (dyad_test.Private.value), try the dyad.WithUnexportedFieldStrategy() option",
+		)))
+	})
+})
@@ -0,0 +1,317 @@
+package dyad
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// navigate calls fn with the value within v that is identified by the given
+// path elements.
+//
+// The value passed to fn is always settable, but may be a copy of a value that
+// is not directly addressable, such as a map element or the value within an
+// interface. Changes made by fn to such copies are discarded. Nothing is
+// written to v.
+//
+// Pointers are dereferenced as necessary, as they do not appear within paths.
+// It skips values within unexported fields when using the
+// IgnoreUnexportedFields strategy, without calling fn.
+func navigate(
+	ctx cloneContext,
+	v reflect.Value,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	return navigator{}.navigate(ctx, v, elems, fn)
+}
+
+// update calls fn with the value within v that is identified by the given
+// path elements, as per navigate().
+//
+// v must be settable. Changes made by fn to copies of values that are not
+// directly addressable are written back to their container after fn returns
+// successfully.
+func update(
+	ctx cloneContext,
+	v reflect.Value,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	return navigator{writeBack: true}.navigate(ctx, v, elems, fn)
+}
+
+// navigator navigates to values within a value.
+type navigator struct {
+	// writeBack indicates whether copies of map elements and interface values
+	// are written back to their container once navigation within them is
+	// complete.
+	writeBack bool
+}
+
+func (n navigator) navigate(
+	ctx cloneContext,
+	v reflect.Value,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	if len(elems) == 0 {
+		return fn(ctx, v)
+	}
+
+	v, err := deref(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	e, elems := elems[0], elems[1:]
+
+	switch e.kind {
+	case fieldElem:
+		return n.navigateField(ctx, v, e, elems, fn)
+	case indexElem:
+		return n.navigateIndex(ctx, v, e, elems, fn)
+	case keyElem:
+		return n.navigateKey(ctx, v, e, elems, fn)
+	case typeElem:
+		return n.navigateType(ctx, v, e, elems, fn)
+	case textElem:
+		return n.navigateText(ctx, v, e, elems, fn)
+	case typeNameElem:
+		return n.navigateTypeName(ctx, v, e, elems, fn)
+	default:
+		return ctx.Error("path contains an element that does not refer to a specific value")
+	}
+}
+
+// relativeElems returns the elements of p that follow its root element, or
+// false if p is not a path within a value of type t.
+func relativeElems(p Path, t reflect.Type) ([]*pathElem, bool) {
+	elems := p.elems()
+
+	if len(elems) == 0 || elems[0].kind != rootElem || elems[0].typ != t {
+		return nil, false
+	}
+
+	return elems[1:], true
+}
+
+// deref dereferences v until it is no longer a pointer.
+func deref(ctx cloneContext, v reflect.Value) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, ctx.Error("cannot navigate through nil pointer")
+		}
+
+		v = v.Elem()
+	}
+
+	return v, nil
+}
+
+func (n navigator) navigateField(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	if v.Kind() != reflect.Struct {
+		return ctx.Error("cannot navigate to field %s of non-struct type %s", e.name, v.Type())
+	}
+
+	field, ok := v.Type().FieldByName(e.name)
+	if !ok || len(field.Index) != 1 {
+		return ctx.Error("%s has no field named %s", v.Type(), e.name)
+	}
+
+	value, ok, err := fieldValue(ctx, v, field.Index[0])
+	if !ok {
+		return err
+	}
+
+	return n.navigate(ctx.WithField(e.name), value, elems, fn)
+}
+
+func (n navigator) navigateIndex(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return ctx.Error("cannot navigate to index %d of non-slice type %s", e.index, v.Type())
+	}
+
+	if e.index < 0 || e.index >= v.Len() {
+		return ctx.Error("index %d is out of range", e.index)
+	}
+
+	return n.navigate(ctx.WithIndex(e.index), v.Index(e.index), elems, fn)
+}
+
+func (n navigator) navigateKey(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	if v.Kind() != reflect.Map {
+		return ctx.Error("cannot navigate to key of non-map type %s", v.Type())
+	}
+
+	key, err := mapKey(ctx, v, e)
+	if err != nil {
+		return err
+	}
+
+	ctx = ctx.WithKey(key)
+
+	elem := v.MapIndex(key)
+	if !elem.IsValid() {
+		return ctx.Error("map does not contain this key")
+	}
+
+	// Map elements are not addressable, so we navigate within a copy of the
+	// element and then write it back to the map, if necessary.
+	value := reflect.New(elem.Type()).Elem()
+	value.Set(elem)
+
+	if err := n.navigate(ctx, value, elems, fn); err != nil {
+		return err
+	}
+
+	if n.writeBack {
+		v.SetMapIndex(key, value)
+	}
+
+	return nil
+}
+
+// mapKey returns the key of the map m that is identified by e.
+//
+// If m does not contain the key exactly, it returns a key of m that is deeply
+// equal to it, if any, such that paths obtained from one value can be used to
+// navigate a clone of that value, even if its keys contain pointers.
+func mapKey(ctx cloneContext, m reflect.Value, e *pathElem) (reflect.Value, error) {
+	keyType := m.Type().Key()
+
+	if !e.key.Type().AssignableTo(keyType) {
+		return reflect.Value{}, ctx.Error("cannot use %s as key of type %s", e.key.Type(), keyType)
+	}
+
+	key := reflect.New(keyType).Elem()
+	key.Set(e.key)
+
+	if m.IsNil() || m.MapIndex(key).IsValid() {
+		return key, nil
+	}
+
+	for _, candidate := range m.MapKeys() {
+		eq, err := equalValues(ctx, key, candidate)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if eq {
+			return candidate, nil
+		}
+	}
+
+	return key, nil
+}
+
+func (n navigator) navigateType(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	if v.Kind() != reflect.Interface {
+		return ctx.Error("cannot navigate to the dynamic type of non-interface type %s", v.Type())
+	}
+
+	if v.IsNil() {
+		return ctx.Error("cannot navigate through nil interface")
+	}
+
+	elem := v.Elem()
+	if elem.Type() != e.typ {
+		return ctx.Error("interface contains %s, not %s", elem.Type(), e.typ)
+	}
+
+	// The value within an interface is not addressable, so we navigate within
+	// a copy of the value and then write it back to the interface, if
+	// necessary.
+	value := reflect.New(elem.Type()).Elem()
+	value.Set(elem)
+
+	if err := n.navigate(ctx.WithType(e.typ), value, elems, fn); err != nil {
+		return err
+	}
+
+	if n.writeBack {
+		v.Set(value)
+	}
+
+	return nil
+}
+
+// navigateText navigates to the slice element or map element identified by the
+// text of an element parsed by ParsePath().
+func (n navigator) navigateText(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(e.name)
+		if err != nil {
+			return ctx.Error("cannot navigate to [%s] of slice type %s", e.name, v.Type())
+		}
+
+		return n.navigateIndex(ctx, v, &pathElem{kind: indexElem, index: i}, elems, fn)
+
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if renderKey(key) == e.name {
+				return n.navigateKey(ctx, v, &pathElem{kind: keyElem, key: key}, elems, fn)
+			}
+		}
+
+		return ctx.Error("map does not contain the key %s", e.name)
+
+	default:
+		return ctx.Error("cannot navigate to [%s] of non-slice, non-map type %s", e.name, v.Type())
+	}
+}
+
+// navigateTypeName navigates to the value within an interface, provided its
+// dynamic type has the name given by an element parsed by ParsePath().
+func (n navigator) navigateTypeName(
+	ctx cloneContext,
+	v reflect.Value,
+	e *pathElem,
+	elems []*pathElem,
+	fn func(cloneContext, reflect.Value) error,
+) error {
+	if v.Kind() != reflect.Interface {
+		return ctx.Error("cannot navigate to the dynamic type of non-interface type %s", v.Type())
+	}
+
+	if v.IsNil() {
+		return ctx.Error("cannot navigate through nil interface")
+	}
+
+	t := v.Elem().Type()
+	if renderTypeName(t) != e.name {
+		return ctx.Error("interface contains %s, not %s", t, e.name)
+	}
+
+	return n.navigateType(ctx, v, &pathElem{kind: typeElem, typ: t}, elems, fn)
+}
@@ -0,0 +1,132 @@
+package dyad
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// An Observer is notified when clone operations start and complete.
+//
+// Observers may be used to collect metrics about clone operations without
+// modifying each call to Clone(). They are called synchronously, and must be
+// safe for concurrent use if Clone() is called concurrently.
+type Observer interface {
+	// CloneStarted is called when Clone() starts cloning a value of type t.
+	CloneStarted(t reflect.Type)
+
+	// CloneCompleted is called when Clone() has finished cloning a value,
+	// whether or not it was successful.
+	CloneCompleted(s CloneStats)
+}
+
+// CloneStats describes a completed clone operation.
+type CloneStats struct {
+	// Type is the type of the cloned value.
+	Type reflect.Type
+
+	// Duration is the time taken to clone the value.
+	Duration time.Duration
+
+	// Nodes is the number of values visited.
+	Nodes int
+
+	// Err is the error that caused Clone() to panic, if any.
+	Err error
+}
+
+// RegisterObserver adds o to the set of observers that are notified about
+// every clone operation.
+//
+// It returns a function that removes o from the set of observers.
+func RegisterObserver(o Observer) (unregister func()) {
+	reg := &registration{o}
+
+	globalObservers.Lock()
+	defer globalObservers.Unlock()
+
+	// The slice is always replaced rather than modified in place, as it may
+	// have already been returned by Observers().
+	globalObservers.list = append(
+		globalObservers.list[:len(globalObservers.list):len(globalObservers.list)],
+		reg,
+	)
+
+	return func() {
+		globalObservers.Lock()
+		defer globalObservers.Unlock()
+
+		var list []*registration
+		for _, r := range globalObservers.list {
+			if r != reg {
+				list = append(list, r)
+			}
+		}
+
+		globalObservers.list = list
+	}
+}
+
+// WithObserver is an option that adds o to the set of observers that are
+// notified about the clone operation, in addition to those registered by
+// RegisterObserver().
+func WithObserver(o Observer) Option {
+	return func(opts *cloneOptions) {
+		opts.observers = append(opts.observers, o)
+	}
+}
+
+var globalObservers struct {
+	sync.RWMutex
+	list []*registration
+}
+
+// registration is an observer registered by RegisterObserver().
+type registration struct {
+	observer Observer
+}
+
+// Observers returns the observers that are notified about a clone operation
+// that uses these options.
+func (o cloneOptions) Observers() []Observer {
+	globalObservers.RLock()
+	global := globalObservers.list
+	globalObservers.RUnlock()
+
+	if len(global) == 0 {
+		return o.observers
+	}
+
+	observers := make([]Observer, 0, len(global)+len(o.observers))
+	for _, r := range global {
+		observers = append(observers, r.observer)
+	}
+
+	return append(observers, o.observers...)
+}
+
+func notifyStarted(observers []Observer, t reflect.Type) {
+	for _, o := range observers {
+		o.CloneStarted(t)
+	}
+}
+
+func notifyCompleted(observers []Observer, t reflect.Type, r *Report, err error) {
+	if len(observers) == 0 {
+		return
+	}
+
+	s := CloneStats{
+		Type:     t,
+		Duration: r.Duration,
+		Err:      err,
+	}
+
+	for _, n := range r.Nodes {
+		s.Nodes += n
+	}
+
+	for _, o := range observers {
+		o.CloneCompleted(s)
+	}
+}
@@ -0,0 +1,69 @@
+package dyad_test
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type observerStub struct {
+	started   []reflect.Type
+	completed []dyad.CloneStats
+}
+
+func (o *observerStub) CloneStarted(t reflect.Type) {
+	o.started = append(o.started, t)
+}
+
+func (o *observerStub) CloneCompleted(s dyad.CloneStats) {
+	o.completed = append(o.completed, s)
+}
+
+var _ = Describe("func WithObserver()", func() {
+	It("notifies the observer about the clone operation", func() {
+		o := &observerStub{}
+		dyad.Clone([]int{1, 2}, dyad.WithObserver(o))
+
+		Expect(o.started).To(Equal([]reflect.Type{reflect.TypeOf([]int{})}))
+		Expect(o.completed).To(HaveLen(1))
+
+		s := o.completed[0]
+		Expect(s.Type).To(Equal(reflect.TypeOf([]int{})))
+		Expect(s.Duration).To(BeNumerically(">", time.Duration(0)))
+		Expect(s.Nodes).To(Equal(3))
+		Expect(s.Err).ShouldNot(HaveOccurred())
+	})
+
+	It("notifies the observer when the clone operation fails", func() {
+		o := &observerStub{}
+
+		Expect(func() {
+			dyad.Clone(make(chan int), dyad.WithObserver(o))
+		}).To(Panic())
+
+		Expect(o.completed).To(HaveLen(1))
+
+		Expect(o.completed[0].Err).To(MatchError("chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option"))
+	})
+})
+
+var _ = Describe("func RegisterObserver()", func() {
+	It("notifies the observer about every clone operation until it is unregistered", func() {
+		o := &observerStub{}
+		unregister := dyad.RegisterObserver(o)
+
+		dyad.Clone(1)
+		dyad.Clone("<value>")
+		unregister()
+		dyad.Clone(1.5)
+
+		Expect(o.started).To(Equal([]reflect.Type{
+			reflect.TypeOf(1),
+			reflect.TypeOf(""),
+		}))
+		Expect(o.completed).To(HaveLen(2))
+	})
+})
@@ -1,5 +1,10 @@
 package dyad
 
+import (
+	"log/slog"
+	"reflect"
+)
+
 // An Option changes the behavior of a clone operation.
 //
 // The signature of this function is not part of the public API and may change
@@ -9,8 +14,20 @@ type Option func(*cloneOptions)
 type cloneOptions struct {
 	channelStrategy         ChannelStrategy
 	unexportedFieldStrategy UnexportedFieldStrategy
+	sliceMergeStrategy      SliceMergeStrategy
+	transforms              map[reflect.Type]transform
+	redact                  bool
+	masks                   map[reflect.Type]transform
+	report                  *Report
+	logger                  *slog.Logger
+	observers               []Observer
+	fieldTag                string
+	strictConversion        bool
 }
 
+// transform is a function that produces the clone of a value.
+type transform func(Path, reflect.Value) reflect.Value
+
 // ChannelStrategy is an enumeration of strategies that can be used by Clone()
 // when a channel is encountered.
 type ChannelStrategy int
@@ -66,3 +83,80 @@ func WithUnexportedFieldStrategy(s UnexportedFieldStrategy) Option {
 		opts.unexportedFieldStrategy = s
 	}
 }
+
+// SliceMergeStrategy is an enumeration of strategies that can be used by
+// Merge() when a non-nil slice is encountered in the source value.
+type SliceMergeStrategy int
+
+const (
+	// ReplaceSlices causes Merge() to replace the destination slice with a
+	// clone of the source slice.
+	//
+	// This is the default behavior.
+	ReplaceSlices SliceMergeStrategy = iota
+
+	// AppendSlices causes Merge() to append clones of the elements of the
+	// source slice to the destination slice.
+	AppendSlices
+
+	// MergeSlicesByIndex causes Merge() to merge each element of the source
+	// slice into the element at the same index of the destination slice,
+	// appending clones of any elements beyond the end of the destination slice.
+	MergeSlicesByIndex
+)
+
+// WithSliceMergeStrategy is an option that controls how Merge() behaves when it
+// encounters a slice.
+func WithSliceMergeStrategy(s SliceMergeStrategy) Option {
+	return func(opts *cloneOptions) {
+		opts.sliceMergeStrategy = s
+	}
+}
+
+// WithTransform is an option that causes Clone() to use fn to produce the clone
+// of any value of type T.
+//
+// fn is called with the path to the original value, and the value itself. The
+// value it returns is used in place of the clone, without being cloned itself.
+// This allows values to be normalized or substituted during cloning, such as
+// rounding time.Time values. Care must be taken not to return a value that
+// shares data with the original.
+//
+// If this option is used multiple times for the same type, the last one takes
+// precedence.
+func WithTransform[T any](fn func(p Path, v T) T) Option {
+	return func(opts *cloneOptions) {
+		if opts.transforms == nil {
+			opts.transforms = map[reflect.Type]transform{}
+		}
+
+		opts.transforms[typeOf[T]()] = newTransform(fn)
+	}
+}
+
+// WithMask is an option that causes Redact() to use fn to mask redacted fields
+// of type T, rather than leaving them as their zero-value.
+//
+// fn is called with the path to the redacted field, and its original value. The
+// value it returns is used in place of the original, without being cloned.
+//
+// If this option is used multiple times for the same type, the last one takes
+// precedence.
+func WithMask[T any](fn func(p Path, v T) T) Option {
+	return func(opts *cloneOptions) {
+		if opts.masks == nil {
+			opts.masks = map[reflect.Type]transform{}
+		}
+
+		opts.masks[typeOf[T]()] = newTransform(fn)
+	}
+}
+
+// newTransform returns a transform that calls fn.
+func newTransform[T any](fn func(Path, T) T) transform {
+	return func(p Path, v reflect.Value) reflect.Value {
+		x, _ := v.Interface().(T) // v may be a nil interface
+		x = fn(p, x)
+		return reflect.ValueOf(&x).Elem()
+	}
+}
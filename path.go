@@ -0,0 +1,298 @@
+package dyad
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Path identifies a value nested within some root value.
+//
+// Its string representation is the same format used to identify values within
+// error messages, starting with the name of the root value's type.
+type Path struct {
+	last *pathElem
+}
+
+// pathElem is a single element within a path.
+type pathElem struct {
+	parent *pathElem
+	kind   pathElemKind
+
+	// typ is the type of the root value, or the dynamic type of an interface.
+	typ reflect.Type
+
+	// name is the name of a struct field.
+	//
+	// For elements parsed by ParsePath(), it is the text of a map key, slice
+	// index or type name, which is resolved against the value being navigated.
+	name string
+
+	// index is the index of a slice or array element.
+	index int
+
+	// key is the key of a map element.
+	key reflect.Value
+}
+
+// pathElemKind is an enumeration of the kinds of path elements.
+type pathElemKind int
+
+const (
+	rootElem pathElemKind = iota
+	fieldElem
+	indexElem
+	keyElem
+	typeElem
+	anyElemElem
+	anyKeyElem
+	textElem
+	typeNameElem
+)
+
+func rootPath(t reflect.Type) Path {
+	return Path{
+		&pathElem{
+			kind: rootElem,
+			typ:  t,
+		},
+	}
+}
+
+// ParsePath parses the string representation of a path to a value within a
+// value of type T.
+//
+// s must use the same format as Path.String(), starting with the name of T.
+// Map keys and the dynamic types of interfaces are matched against the
+// representation of the values being navigated, so the resulting path can
+// only be used to navigate values that contain those keys and types.
+func ParsePath[T any](s string) (Path, error) {
+	t := typeOf[T]()
+	p := rootPath(t)
+
+	root := renderTypeName(t)
+	if !strings.HasPrefix(s, root) {
+		return Path{}, pathSyntaxError(s, "expected path to begin with %s", root)
+	}
+
+	for rest := s[len(root):]; rest != ""; {
+		var (
+			e   *pathElem
+			n   int
+			err error
+		)
+
+		switch rest[0] {
+		case '.':
+			e, n, err = parseFieldElem(rest)
+		case '[':
+			e, n, err = parseBracketElem(rest)
+		case '(':
+			e, n, err = parseTypeElem(rest)
+		default:
+			err = fmt.Errorf("unexpected %q", rest[0])
+		}
+
+		if err != nil {
+			return Path{}, pathSyntaxError(s, "%s at offset %d", err, len(s)-len(rest))
+		}
+
+		p = p.append(e)
+		rest = rest[n:]
+	}
+
+	return p, nil
+}
+
+// pathSyntaxError returns the error that occurs when s cannot be parsed as a
+// path.
+func pathSyntaxError(s, format string, args ...any) error {
+	return fmt.Errorf("cannot parse path %q: %s", s, fmt.Sprintf(format, args...))
+}
+
+// parseFieldElem parses a struct field element at the start of s, returning
+// the element and the number of bytes consumed.
+func parseFieldElem(s string) (*pathElem, int, error) {
+	n := 1
+	for n < len(s) && isIdentByte(s[n]) {
+		n++
+	}
+
+	if n == 1 {
+		return nil, 0, errors.New("expected field name")
+	}
+
+	return &pathElem{kind: fieldElem, name: s[1:n]}, n, nil
+}
+
+// isIdentByte returns true if c can appear within a Go identifier.
+func isIdentByte(c byte) bool {
+	return c == '_' ||
+		c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c >= utf8.RuneSelf
+}
+
+// parseBracketElem parses an index or key element at the start of s,
+// returning the element and the number of bytes consumed.
+func parseBracketElem(s string) (*pathElem, int, error) {
+	text, n, err := scanDelimited(s, ']')
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch text {
+	case "*":
+		return &pathElem{kind: anyElemElem}, n, nil
+	case "key":
+		return &pathElem{kind: anyKeyElem}, n, nil
+	default:
+		return &pathElem{kind: textElem, name: text}, n, nil
+	}
+}
+
+// parseTypeElem parses an interface's dynamic type element at the start of s,
+// returning the element and the number of bytes consumed.
+func parseTypeElem(s string) (*pathElem, int, error) {
+	text, n, err := scanDelimited(s, ')')
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &pathElem{kind: typeNameElem, name: text}, n, nil
+}
+
+// scanDelimited returns the text between the opening delimiter at the start
+// of s and the matching closing delimiter, along with the number of bytes
+// consumed, including both delimiters.
+//
+// Nested brackets and quoted strings within the text are skipped, such that
+// map keys like ["]"] and type names like (func() error) are supported.
+func scanDelimited(s string, closing byte) (string, int, error) {
+	var stack []byte
+
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\'', '`':
+			q, err := strconv.QuotedPrefix(s[i:])
+			if err != nil {
+				return "", 0, errors.New("unterminated quoted string")
+			}
+			i += len(q) - 1
+		case '[':
+			stack = append(stack, ']')
+		case '(':
+			stack = append(stack, ')')
+		case '{':
+			stack = append(stack, '}')
+		case ']', ')', '}':
+			if len(stack) != 0 {
+				if stack[len(stack)-1] != c {
+					return "", 0, fmt.Errorf("unexpected %q", c)
+				}
+				stack = stack[:len(stack)-1]
+			} else if c != closing {
+				return "", 0, fmt.Errorf("unexpected %q", c)
+			} else if i == 1 {
+				return "", 0, errors.New("unexpected empty element")
+			} else {
+				return s[1:i], i + 1, nil
+			}
+		}
+	}
+
+	return "", 0, fmt.Errorf("expected closing %q", closing)
+}
+
+func (p Path) append(e *pathElem) Path {
+	e.parent = p.last
+	return Path{e}
+}
+
+// String returns the string representation of the path.
+func (p Path) String() string {
+	w := &strings.Builder{}
+
+	for _, e := range p.elems() {
+		e.write(w)
+	}
+
+	return w.String()
+}
+
+// elems returns the elements of the path, starting with the root.
+func (p Path) elems() []*pathElem {
+	var elems []*pathElem
+
+	for e := p.last; e != nil; e = e.parent {
+		elems = append(elems, e)
+	}
+
+	for i, j := 0, len(elems)-1; i < j; i, j = i+1, j-1 {
+		elems[i], elems[j] = elems[j], elems[i]
+	}
+
+	return elems
+}
+
+func (e *pathElem) write(w *strings.Builder) {
+	switch e.kind {
+	case rootElem:
+		w.WriteString(renderTypeName(e.typ))
+	case fieldElem:
+		fmt.Fprintf(w, ".%s", e.name)
+	case indexElem:
+		fmt.Fprintf(w, "[%d]", e.index)
+	case keyElem:
+		fmt.Fprintf(w, "[%s]", renderKey(e.key))
+	case typeElem:
+		fmt.Fprintf(w, "(%s)", renderTypeName(e.typ))
+	case anyElemElem:
+		w.WriteString("[*]")
+	case anyKeyElem:
+		w.WriteString("[key]")
+	case textElem:
+		fmt.Fprintf(w, "[%s]", e.name)
+	case typeNameElem:
+		fmt.Fprintf(w, "(%s)", e.name)
+	}
+}
+
+// renderKey returns the representation of the map key k within a path.
+func renderKey(k reflect.Value) string {
+	return fmt.Sprintf("%#v", k.Interface())
+}
+
+// sortedMapKeys returns the keys of the map m, sorted by their representation
+// within a path.
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	names := make([]string, len(keys))
+
+	for i, k := range keys {
+		names[i] = renderKey(k)
+	}
+
+	sort.Sort(keysByName{keys, names})
+
+	return keys
+}
+
+// keysByName sorts map keys by their representation within a path.
+type keysByName struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (s keysByName) Len() int           { return len(s.keys) }
+func (s keysByName) Less(i, j int) bool { return s.names[i] < s.names[j] }
+
+func (s keysByName) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+}
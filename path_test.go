@@ -0,0 +1,91 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func ParsePath()", func() {
+	type Value struct {
+		Name      string
+		Slice     []int
+		Map       map[string]int
+		Interface any
+	}
+
+	DescribeTable(
+		"it parses the string representation of a path",
+		func(s string) {
+			p, err := dyad.ParsePath[Value](s)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.String()).To(Equal(s))
+		},
+		Entry("root", `dyad_test.Value`),
+		Entry("field", `dyad_test.Value.Name`),
+		Entry("index", `dyad_test.Value.Slice[1]`),
+		Entry("key", `dyad_test.Value.Map["<key>"]`),
+		Entry("key containing delimiters", `dyad_test.Value.Map["]\")"]`),
+		Entry("dynamic type", `dyad_test.Value.Interface(map[string]int)`),
+		Entry("nested dynamic type", `dyad_test.Value.Interface(func() (int, error))`),
+		Entry("any element", `dyad_test.Value.Slice[*]`),
+		Entry("any key", `dyad_test.Value.Map[key]`),
+	)
+
+	It("matches the string representation of paths produced by Diff()", func() {
+		a := Value{Map: map[string]int{"<key>": 1}, Interface: []int{1}}
+		b := Value{Map: map[string]int{"<key>": 2}, Interface: []int{2}}
+
+		for _, d := range dyad.Diff(a, b) {
+			p, err := dyad.ParsePath[Value](d.Path.String())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			x, err := dyad.Get(b, p)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(x).To(Equal(d.B))
+		}
+	})
+
+	DescribeTable(
+		"it returns an error if the path is invalid",
+		func(s, expect string) {
+			_, err := dyad.ParsePath[Value](s)
+			Expect(err).To(MatchError(expect))
+		},
+		Entry(
+			"wrong root type",
+			`dyad_test.Other.Name`,
+			`cannot parse path "dyad_test.Other.Name": expected path to begin with dyad_test.Value`,
+		),
+		Entry(
+			"missing field name",
+			`dyad_test.Value.`,
+			`cannot parse path "dyad_test.Value.": expected field name at offset 15`,
+		),
+		Entry(
+			"unterminated bracket",
+			`dyad_test.Value.Slice[1`,
+			`cannot parse path "dyad_test.Value.Slice[1": expected closing ']' at offset 21`,
+		),
+		Entry(
+			"mismatched bracket",
+			`dyad_test.Value.Slice[1)`,
+			`cannot parse path "dyad_test.Value.Slice[1)": unexpected ')' at offset 21`,
+		),
+		Entry(
+			"empty element",
+			`dyad_test.Value.Slice[]`,
+			`cannot parse path "dyad_test.Value.Slice[]": unexpected empty element at offset 21`,
+		),
+		Entry(
+			"unterminated string",
+			`dyad_test.Value.Map["x]`,
+			`cannot parse path "dyad_test.Value.Map[\"x]": unterminated quoted string at offset 19`,
+		),
+		Entry(
+			"unexpected character",
+			`dyad_test.Value!`,
+			`cannot parse path "dyad_test.Value!": unexpected '!' at offset 15`,
+		),
+	)
+})
@@ -0,0 +1,36 @@
+package dyad
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Redact returns a deep copy of src with sensitive fields redacted.
+//
+// Struct fields with a `dyad:"redact"` tag are left as their zero-value within
+// the copy, unless a mask has been registered for the field's type using the
+// WithMask() option. Redacted fields are found anywhere within src, including
+// within slices, maps and interfaces. src itself is never modified.
+//
+// Aside from redaction, it behaves the same as Clone().
+func Redact[T any](src T, options ...Option) T {
+	options = append(
+		options[:len(options):len(options)], // never modify the caller's slice
+		func(opts *cloneOptions) {
+			opts.redact = true
+		},
+	)
+
+	return Clone(src, options...)
+}
+
+// isRedacted returns true if f has a `dyad:"redact"` tag.
+func isRedacted(f reflect.StructField) bool {
+	for _, opt := range strings.Split(f.Tag.Get("dyad"), ",") {
+		if opt == "redact" {
+			return true
+		}
+	}
+
+	return false
+}
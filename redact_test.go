@@ -0,0 +1,72 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Redact()", func() {
+	type Credentials struct {
+		Username string
+		Password string `dyad:"redact"`
+	}
+
+	type Request struct {
+		Credentials Credentials
+		History     []Credentials
+		Extra       map[string]any
+		Token       *string `dyad:"redact"`
+	}
+
+	It("zeroes redacted fields within the copy", func() {
+		token := "<token>"
+		src := Request{
+			Credentials: Credentials{"<user>", "<password>"},
+			History:     []Credentials{{"<old-user>", "<old-password>"}},
+			Extra:       map[string]any{"<key>": Credentials{"<other-user>", "<other-password>"}},
+			Token:       &token,
+		}
+
+		dst := dyad.Redact(src)
+
+		Expect(dst).To(Equal(Request{
+			Credentials: Credentials{"<user>", ""},
+			History:     []Credentials{{"<old-user>", ""}},
+			Extra:       map[string]any{"<key>": Credentials{"<other-user>", ""}},
+		}))
+	})
+
+	It("does not modify the original value", func() {
+		src := Request{
+			Credentials: Credentials{"<user>", "<password>"},
+		}
+
+		dyad.Redact(src)
+
+		Expect(src.Credentials.Password).To(Equal("<password>"))
+	})
+
+	It("masks redacted fields using the registered mask", func() {
+		src := Credentials{"<user>", "<password>"}
+
+		var paths []string
+		dst := dyad.Redact(
+			src,
+			dyad.WithMask(func(p dyad.Path, v string) string {
+				paths = append(paths, p.String())
+				return "[REDACTED]"
+			}),
+		)
+
+		Expect(dst).To(Equal(Credentials{"<user>", "[REDACTED]"}))
+		Expect(paths).To(Equal([]string{"dyad_test.Credentials.Password"}))
+	})
+
+	It("does not redact fields when using Clone()", func() {
+		src := Credentials{"<user>", "<password>"}
+		dst := dyad.Clone(src)
+
+		Expect(dst).To(Equal(src))
+	})
+})
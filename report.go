@@ -0,0 +1,184 @@
+package dyad
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+// WithReport is an option that causes Clone() to populate r with statistics
+// about the clone operation.
+//
+// Any existing content of r is replaced.
+func WithReport(r *Report) Option {
+	return func(opts *cloneOptions) {
+		opts.report = r
+	}
+}
+
+// A Report contains statistics about a clone operation.
+type Report struct {
+	// Nodes is the number of values visited, by kind.
+	Nodes map[reflect.Kind]int
+
+	// Allocations is the number of pointers, slices, maps and interface values
+	// allocated for the clone.
+	Allocations int
+
+	// BytesCopied is the total size of the values that were copied directly
+	// from the source value, such as strings, numbers and time.Time values.
+	//
+	// The size of a value is the size of its type, as returned by
+	// reflect.Type.Size(), and therefore does not include the content of
+	// strings, which is shared between the source value and the clone.
+	BytesCopied int
+
+	// MaxDepth is the maximum depth at which a value was visited, where the
+	// root value has a depth of 1.
+	MaxDepth int
+
+	// Duration is the time taken to clone the value.
+	Duration time.Duration
+
+	// Events is a list of non-fatal events that occurred during the clone
+	// operation, such as values that were not cloned due to the chosen
+	// strategies.
+	//
+	// Events of the TimeShared and TransformApplied kinds are expected
+	// outcomes of cloning, and are only logged when using the WithLogger()
+	// option. They are never recorded in the report.
+	Events []Event
+}
+
+// WithLogger is an option that causes Clone() to log each decision it makes
+// that affects the content of the clone, such as sharing channels and ignoring
+// unexported fields.
+//
+// Each decision is logged at the debug level, with "path" and "type" attributes
+// describing the value that is affected. The same decisions are recorded as
+// events when using the WithReport() option, except for those of the
+// TimeShared and TransformApplied kinds, which are only logged.
+func WithLogger(l *slog.Logger) Option {
+	return func(opts *cloneOptions) {
+		opts.logger = l
+	}
+}
+
+// An Event describes a non-fatal event that occurred during a clone operation.
+type Event struct {
+	// Path is the path to the value that caused the event.
+	Path Path
+
+	// Kind is the kind of event.
+	Kind EventKind
+
+	// Type is the type of the value that caused the event.
+	Type reflect.Type
+}
+
+// String returns a human-readable description of the event.
+func (e Event) String() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Kind)
+}
+
+// EventKind is an enumeration of the kinds of non-fatal events that can occur
+// during a clone operation.
+type EventKind int
+
+const (
+	// ChannelShared indicates that a channel was shared between the source
+	// value and the clone, due to the ShareChannels strategy.
+	ChannelShared EventKind = iota
+
+	// ChannelIgnored indicates that a channel was replaced with nil, due to
+	// the IgnoreChannels strategy.
+	ChannelIgnored
+
+	// UnexportedFieldIgnored indicates that an unexported field was left as
+	// its zero-value, due to the IgnoreUnexportedFields strategy.
+	UnexportedFieldIgnored
+
+	// TimeShared indicates that a time.Time value was copied without cloning
+	// its location.
+	//
+	// Events of this kind are only logged, and are never recorded in a Report.
+	TimeShared
+
+	// TransformApplied indicates that a value was replaced by the result of a
+	// function registered using WithTransform().
+	//
+	// Events of this kind are only logged, and are never recorded in a Report.
+	TransformApplied
+
+	// FieldRedacted indicates that a field was zeroed or masked by Redact().
+	FieldRedacted
+)
+
+var eventKindNames = map[EventKind]string{
+	ChannelShared:          "channel shared",
+	ChannelIgnored:         "channel ignored",
+	UnexportedFieldIgnored: "unexported field ignored",
+	TimeShared:             "time.Time shared",
+	TransformApplied:       "transform applied",
+	FieldRedacted:          "field redacted",
+}
+
+// String returns a human-readable representation of the event kind.
+func (k EventKind) String() string {
+	if n, ok := eventKindNames[k]; ok {
+		return n
+	}
+
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+// Visit records that v has been visited.
+func (c cloneContext) Visit(v reflect.Value) {
+	if r := c.options.report; r != nil {
+		if r.Nodes == nil {
+			r.Nodes = map[reflect.Kind]int{}
+		}
+
+		r.Nodes[v.Kind()]++
+		r.MaxDepth = max(r.MaxDepth, c.depth)
+	}
+}
+
+// Allocated records that a value has been allocated for the clone.
+func (c cloneContext) Allocated() {
+	if r := c.options.report; r != nil {
+		r.Allocations++
+	}
+}
+
+// Copied records that a value of type t has been copied from the source value.
+func (c cloneContext) Copied(t reflect.Type) {
+	if r := c.options.report; r != nil {
+		r.BytesCopied += int(t.Size())
+	}
+}
+
+// Event records an event caused by a value of type t at the current path.
+func (c cloneContext) Event(k EventKind, t reflect.Type) {
+	if r := c.options.report; r != nil {
+		r.Events = append(r.Events, Event{c.path, k, t})
+	}
+
+	c.Trace(k, t)
+}
+
+// Trace logs an event caused by a value of type t at the current path, without
+// recording it in the report.
+func (c cloneContext) Trace(k EventKind, t reflect.Type) {
+	if l := c.options.logger; l != nil && l.Enabled(context.Background(), slog.LevelDebug) {
+		l.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			k.String(),
+			slog.String("path", c.path.String()),
+			slog.String("type", renderTypeName(t)),
+		)
+	}
+}
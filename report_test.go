@@ -0,0 +1,110 @@
+package dyad_test
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func WithReport()", func() {
+	It("reports statistics about the clone operation", func() {
+		type Value struct {
+			Name  string
+			Ptr   *int64
+			Slice []int32
+			Map   map[string]bool
+		}
+
+		n := int64(123)
+		src := Value{
+			Name:  "<name>",
+			Ptr:   &n,
+			Slice: []int32{1, 2},
+			Map:   map[string]bool{"<key>": true},
+		}
+
+		var r dyad.Report
+		dyad.Clone(src, dyad.WithReport(&r))
+
+		Expect(r.Nodes).To(Equal(map[reflect.Kind]int{
+			reflect.Struct: 1,
+			reflect.String: 2,
+			reflect.Ptr:    1,
+			reflect.Int64:  1,
+			reflect.Slice:  1,
+			reflect.Int32:  2,
+			reflect.Map:    1,
+			reflect.Bool:   1,
+		}))
+		Expect(r.Allocations).To(Equal(3))
+		Expect(r.BytesCopied).To(Equal(2*16 + 8 + 2*4 + 1))
+		Expect(r.MaxDepth).To(Equal(3))
+		Expect(r.Duration).To(BeNumerically(">", 0))
+		Expect(r.Events).To(BeEmpty())
+	})
+
+	It("reports values that are not cloned due to the chosen strategies", func() {
+		type Value struct {
+			Channel    chan int
+			unexported string
+		}
+
+		src := Value{
+			Channel: make(chan int),
+		}
+
+		var r dyad.Report
+		dyad.Clone(
+			src,
+			dyad.WithReport(&r),
+			dyad.WithChannelStrategy(dyad.ShareChannels),
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)
+
+		Expect(r.Events).To(HaveLen(2))
+		Expect(r.Events[0].String()).To(Equal("dyad_test.Value.Channel: channel shared"))
+		Expect(r.Events[1].String()).To(Equal("dyad_test.Value.unexported: unexported field ignored"))
+
+		dyad.Clone(
+			src,
+			dyad.WithReport(&r),
+			dyad.WithChannelStrategy(dyad.IgnoreChannels),
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)
+
+		Expect(r.Events).To(HaveLen(2))
+		Expect(r.Events[0].Kind).To(Equal(dyad.ChannelIgnored))
+		Expect(r.Events[1].Kind).To(Equal(dyad.UnexportedFieldIgnored))
+	})
+
+	It("does not report values that are shared or transformed as expected", func() {
+		type Value struct {
+			Time time.Time
+			Name string
+		}
+
+		var r dyad.Report
+		dyad.Clone(
+			Value{time.Now(), "<name>"},
+			dyad.WithReport(&r),
+			dyad.WithTransform(func(p dyad.Path, v string) string {
+				return v
+			}),
+		)
+
+		Expect(r.Events).To(BeEmpty())
+	})
+
+	It("replaces the existing content of the report", func() {
+		r := dyad.Report{
+			Allocations: 100,
+		}
+
+		dyad.Clone(0, dyad.WithReport(&r))
+
+		Expect(r.Allocations).To(Equal(0))
+	})
+})
@@ -0,0 +1,123 @@
+package dyad
+
+import "reflect"
+
+// A Session clones multiple values such that data shared between them is also
+// shared between their clones.
+//
+// Within a session, each pointer, slice or map is cloned at most once. If two
+// values cloned within the same session refer to the same data, their clones
+// refer to the same clone of that data. This applies both to data shared
+// between separate values, and to data shared within a single value, including
+// cyclic values.
+//
+// Slices are only considered to refer to the same data if they have the same
+// backing array, length and capacity.
+//
+// The clone of each pointer, slice or map can be obtained using Lookup(), such
+// that references to the original data held outside of the cloned values can
+// be translated to refer to the clones.
+//
+// A Session is not safe for concurrent use.
+type Session struct {
+	ctx cloneContext
+}
+
+// NewSession returns a new session that clones values using the given
+// options.
+func NewSession(options ...Option) *Session {
+	ctx := newCloneContext(options)
+	ctx.identities = map[identity]clonedReference{}
+
+	return &Session{ctx}
+}
+
+// CloneWithin returns a deep copy of src, cloned within the session s.
+//
+// It behaves the same as Clone(), except that data that has already been
+// cloned within s is shared rather than cloned again.
+func CloneWithin[T any](s *Session, src T) T {
+	dst, err := cloneWithin(s.ctx, src)
+	if err != nil {
+		panic(err)
+	}
+
+	return dst
+}
+
+// Lookup returns the clone of src, which must be a pointer, slice or map that
+// has been cloned within the session.
+//
+// The returned value has the same type as src. It returns false if src has not
+// been cloned within the session, such as when it is nil, it is not a pointer,
+// slice or map, or it is only reachable via a value that was not cloned
+// because of the chosen strategies. A slice is only found if it has the same
+// length and capacity as the slice that was cloned.
+func (s *Session) Lookup(src any) (any, bool) {
+	v := reflect.ValueOf(src)
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	dst, ok := s.ctx.cloneOf(v)
+	if !ok {
+		return nil, false
+	}
+
+	return dst.Interface(), true
+}
+
+// identity identifies the data referred to by a pointer, slice or map.
+type identity struct {
+	ref      reference
+	len, cap int
+}
+
+// identityOf returns the identity of the data referred to by v, which must be
+// a pointer, slice or map.
+func identityOf(v reflect.Value) identity {
+	id := identity{ref: referenceTo(v)}
+
+	if v.Kind() == reflect.Slice {
+		id.len = v.Len()
+		id.cap = v.Cap()
+	}
+
+	return id
+}
+
+// clonedReference is a pointer, slice or map that has been cloned within a
+// session.
+type clonedReference struct {
+	// src is the original value. It is retained so that its memory is not
+	// reused by some other value while the session is in use.
+	src reflect.Value
+
+	// dst is the clone of src.
+	dst reflect.Value
+}
+
+// cloneOf returns the existing clone of src, which must be a pointer, slice or
+// map, if it has already been cloned within the current session.
+func (c cloneContext) cloneOf(src reflect.Value) (reflect.Value, bool) {
+	if c.identities == nil {
+		return reflect.Value{}, false
+	}
+
+	r, ok := c.identities[identityOf(src)]
+	return r.dst, ok
+}
+
+// remember records dst as the clone of src, which must be a pointer, slice or
+// map, within the current session.
+func (c cloneContext) remember(src, dst reflect.Value) {
+	if c.identities != nil {
+		c.identities[identityOf(src)] = clonedReference{src, dst}
+	}
+}
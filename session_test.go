@@ -0,0 +1,174 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("type Session", func() {
+	type Entity struct {
+		ID string
+	}
+
+	type Repository struct {
+		Entities []*Entity
+	}
+
+	type Index struct {
+		ByID map[string]*Entity
+	}
+
+	Describe("func CloneWithin()", func() {
+		It("preserves data shared between separately cloned values", func() {
+			e := &Entity{"<id>"}
+			repo := Repository{Entities: []*Entity{e}}
+			index := Index{ByID: map[string]*Entity{"<id>": e}}
+
+			s := dyad.NewSession()
+			repoClone := dyad.CloneWithin(s, repo)
+			indexClone := dyad.CloneWithin(s, index)
+
+			Expect(repoClone).To(Equal(repo))
+			Expect(indexClone).To(Equal(index))
+
+			Expect(repoClone.Entities[0]).NotTo(BeIdenticalTo(e))
+			Expect(indexClone.ByID["<id>"]).To(BeIdenticalTo(repoClone.Entities[0]))
+		})
+
+		It("preserves data shared within a single value", func() {
+			type Pair struct {
+				A, B  *Entity
+				X, Y  []int
+				M, N  map[string]int
+				Other []int
+			}
+
+			e := &Entity{"<id>"}
+			s := []int{1, 2}
+			m := map[string]int{"<key>": 1}
+
+			src := Pair{e, e, s, s, m, m, s[:1]}
+			dst := dyad.CloneWithin(dyad.NewSession(), src)
+
+			Expect(dst).To(Equal(src))
+			Expect(dst.A).To(BeIdenticalTo(dst.B))
+			Expect(dst.A).NotTo(BeIdenticalTo(e))
+
+			dst.X[0] = 100
+			Expect(dst.Y[0]).To(Equal(100))
+			Expect(dst.Other[0]).To(Equal(1)) // different length, not shared
+			Expect(s[0]).To(Equal(1))
+
+			dst.M["<key>"] = 100
+			Expect(dst.N["<key>"]).To(Equal(100))
+			Expect(m["<key>"]).To(Equal(1))
+		})
+
+		It("supports cyclic values", func() {
+			type Node struct {
+				Value int
+				Next  *Node
+			}
+
+			n := &Node{Value: 1}
+			n.Next = n
+
+			dst := dyad.CloneWithin(dyad.NewSession(), n)
+
+			Expect(dst).NotTo(BeIdenticalTo(n))
+			Expect(dst.Value).To(Equal(1))
+			Expect(dst.Next).To(BeIdenticalTo(dst))
+		})
+
+		It("does not preserve sharing between separate sessions", func() {
+			e := &Entity{"<id>"}
+
+			a := dyad.CloneWithin(dyad.NewSession(), e)
+			b := dyad.CloneWithin(dyad.NewSession(), e)
+
+			Expect(a).NotTo(BeIdenticalTo(b))
+		})
+
+		It("uses the options passed to NewSession()", func() {
+			type Channel struct {
+				C chan int
+			}
+
+			s := dyad.NewSession(dyad.WithChannelStrategy(dyad.IgnoreChannels))
+			dst := dyad.CloneWithin(s, Channel{make(chan int)})
+
+			Expect(dst.C).To(BeNil())
+		})
+
+		It("panics if the value cannot be cloned", func() {
+			Expect(func() {
+				dyad.CloneWithin(dyad.NewSession(), make(chan int))
+			}).To(PanicWith(MatchError(
+				"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+			)))
+		})
+	})
+
+	Describe("func (*Session) Lookup()", func() {
+		It("returns the clone of a pointer, slice or map", func() {
+			type Graph struct {
+				Root  *Entity
+				Slice []*Entity
+				Map   map[string]*Entity
+			}
+
+			e := &Entity{"<id>"}
+			src := Graph{
+				Root:  e,
+				Slice: []*Entity{e},
+				Map:   map[string]*Entity{"<id>": e},
+			}
+
+			s := dyad.NewSession()
+			dst := dyad.CloneWithin(s, src)
+
+			x, ok := s.Lookup(e)
+			Expect(ok).To(BeTrue())
+			Expect(x).To(BeIdenticalTo(dst.Root))
+
+			x, ok = s.Lookup(src.Slice)
+			Expect(ok).To(BeTrue())
+			Expect(x).To(HaveLen(1))
+			x.([]*Entity)[0] = nil
+			Expect(dst.Slice[0]).To(BeNil())
+
+			x, ok = s.Lookup(src.Map)
+			Expect(ok).To(BeTrue())
+			x.(map[string]*Entity)["<other>"] = nil
+			Expect(dst.Map).To(HaveKey("<other>"))
+		})
+
+		It("returns false if the value has not been cloned within the session", func() {
+			s := dyad.NewSession()
+			dyad.CloneWithin(s, []int{1, 2, 3})
+
+			_, ok := s.Lookup(&Entity{})
+			Expect(ok).To(BeFalse())
+
+			_, ok = s.Lookup((*Entity)(nil))
+			Expect(ok).To(BeFalse())
+
+			_, ok = s.Lookup(123)
+			Expect(ok).To(BeFalse())
+
+			_, ok = s.Lookup(nil)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("returns false for slices with a different length", func() {
+			src := []int{1, 2, 3}
+
+			s := dyad.NewSession()
+			dyad.CloneWithin(s, src)
+
+			_, ok := s.Lookup(src[:1])
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,244 @@
+package dyad
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// SharedReferences returns the paths at which a and b refer to the same
+// memory.
+//
+// A pair of paths is reported for each pointer, map, slice backing array or
+// channel within a that refers to the same memory as some pointer, map, slice
+// or channel within b, including those within map keys, which are reported
+// at the path of the corresponding map element. Slices are considered to share
+// memory if their backing arrays overlap, up to their capacity. Values that Clone() shares rather than
+// copying because they are immutable, such as time.Time, are not reported.
+//
+// The values are traversed in the same way as Walk(). Unexported fields and
+// channels are included by default, as though the CloneUnexportedFields and
+// ShareChannels strategies were in use.
+//
+// It returns an empty slice if a and b are fully independent, such as when b
+// is a clone of a. Otherwise, it panics under the same circumstances as
+// Clone().
+func SharedReferences[A, B any](a A, b B, options ...Option) []SharedReference {
+	options = append(
+		[]Option{
+			WithUnexportedFieldStrategy(CloneUnexportedFields),
+			WithChannelStrategy(ShareChannels),
+		},
+		options...,
+	)
+
+	aRegions, err := collectRegions(a, 0, options)
+	if err != nil {
+		panic(err)
+	}
+
+	bRegions, err := collectRegions(b, 1, options)
+	if err != nil {
+		panic(err)
+	}
+
+	var shared []SharedReference
+
+	overlappingRegions(
+		append(aRegions, bRegions...),
+		func(x, y memoryRegion) {
+			if x.side == y.side {
+				return
+			}
+
+			if x.side == 1 {
+				x, y = y, x
+			}
+
+			shared = append(shared, SharedReference{x.path, y.path})
+		},
+	)
+
+	sort.Slice(shared, func(i, j int) bool {
+		if a, b := shared[i].A.String(), shared[j].A.String(); a != b {
+			return a < b
+		}
+		return shared[i].B.String() < shared[j].B.String()
+	})
+
+	return shared
+}
+
+// SharedReference describes a pair of paths within two values that refer to
+// the same memory.
+type SharedReference struct {
+	// A is the path within the first value.
+	A Path
+
+	// B is the path within the second value.
+	B Path
+}
+
+func (r SharedReference) String() string {
+	return fmt.Sprintf("%s and %s refer to the same memory", r.A, r.B)
+}
+
+// memoryRegion is a region of memory that is referred to by the value at a
+// specific path.
+type memoryRegion struct {
+	start, end uintptr
+	path       Path
+
+	// side identifies the value that contains path, when regions from multiple
+	// values are compared.
+	side int
+}
+
+// collectRegions returns the regions of memory referred to by the pointers,
+// slices, maps and channels within v, including those within map keys.
+//
+// Each path that refers to some memory produces a region, but the values
+// within that memory are only walked the first time it is encountered.
+func collectRegions[T any](
+	v T,
+	side int,
+	options []Option,
+) ([]memoryRegion, error) {
+	root := reflect.ValueOf(&v).Elem()
+	c := &regionCollector{
+		side: side,
+		seen: map[reference]struct{}{},
+	}
+
+	err := c.Walk(
+		newCloneContext(options).WithRoot(root.Type()),
+		root,
+	)
+
+	return c.regions, err
+}
+
+// regionCollector collects the regions of memory referred to by a value.
+type regionCollector struct {
+	regions []memoryRegion
+	side    int
+
+	// seen is the set of references whose values have already been walked.
+	seen map[reference]struct{}
+
+	// err is any error that occurred while walking map keys.
+	err error
+}
+
+func (c *regionCollector) Walk(ctx cloneContext, v reflect.Value) error {
+	w := &walker{
+		inProgress: map[reference]struct{}{},
+	}
+
+	w.visit = func(p Path, v reflect.Value, k reflect.Kind) WalkAction {
+		start, size, ok := regionOf(v, k)
+		if !ok {
+			return ContinueWalk
+		}
+
+		c.regions = append(c.regions, memoryRegion{start, start + size, p, c.side})
+
+		ref := referenceTo(v)
+		if _, ok := c.seen[ref]; ok {
+			return SkipChildren
+		}
+
+		c.seen[ref] = struct{}{}
+
+		if k == reflect.Map {
+			return c.walkKeys(ctx, p, v)
+		}
+
+		return ContinueWalk
+	}
+
+	if err := w.Walk(ctx, v); err != nil {
+		return err
+	}
+
+	return c.err
+}
+
+// walkKeys collects the regions referred to by the keys of the map v, which is
+// at the path p.
+func (c *regionCollector) walkKeys(ctx cloneContext, p Path, v reflect.Value) WalkAction {
+	// Walk() does not visit map keys, so we walk them separately to collect
+	// any memory they refer to.
+	ctx.path = p
+	for _, k := range sortedMapKeys(v) {
+		if err := c.Walk(ctx.WithKey(k), k); err != nil {
+			c.err = err
+			return StopWalk
+		}
+	}
+
+	return ContinueWalk
+}
+
+// regionOf returns the start and size of the memory referred to by v, which
+// has kind k, or false if v does not refer to any memory.
+func regionOf(v reflect.Value, k reflect.Kind) (uintptr, uintptr, bool) {
+	switch k {
+	case reflect.Ptr:
+		if v.IsNil() || v.Type().Elem().Size() == 0 {
+			return 0, 0, false
+		}
+		return v.Pointer(), v.Type().Elem().Size(), true
+
+	case reflect.Slice:
+		if v.IsNil() || v.Cap() == 0 || v.Type().Elem().Size() == 0 {
+			return 0, 0, false
+		}
+		return v.Pointer(), uintptr(v.Cap()) * v.Type().Elem().Size(), true
+
+	case reflect.Map, reflect.Chan:
+		if v.IsNil() {
+			return 0, 0, false
+		}
+		// The memory used by maps and channels is not accessible to other
+		// values, so they can only be shared by referring to the same one.
+		return v.Pointer(), 1, true
+
+	default:
+		return 0, 0, false
+	}
+}
+
+// overlappingRegions calls fn for each pair of regions that overlap.
+//
+// The regions are sorted in place by their starting address. Within each pair,
+// x starts at or before y.
+func overlappingRegions(
+	regions []memoryRegion,
+	fn func(x, y memoryRegion),
+) {
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].start < regions[j].start
+	})
+
+	var active []memoryRegion
+
+	for _, r := range regions {
+		// Remove regions that end before r starts, as they can not overlap r
+		// or any region after it.
+		n := 0
+		for _, x := range active {
+			if x.end > r.start {
+				active[n] = x
+				n++
+			}
+		}
+		active = active[:n]
+
+		for _, x := range active {
+			fn(x, r)
+		}
+
+		active = append(active, r)
+	}
+}
@@ -0,0 +1,124 @@
+package dyad_test
+
+import (
+	"time"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func SharedReferences()", func() {
+	type Value struct {
+		Ptr     *int
+		Slice   []int
+		Map     map[string]int
+		Channel chan int
+		Time    time.Time
+		private *int
+	}
+
+	newValue := func() Value {
+		n, m := 1, 2
+		return Value{
+			Ptr:     &n,
+			Slice:   []int{1, 2, 3},
+			Map:     map[string]int{"<key>": 1},
+			Channel: make(chan int),
+			Time:    time.Now(),
+			private: &m,
+		}
+	}
+
+	render := func(shared []dyad.SharedReference) []string {
+		var lines []string
+		for _, r := range shared {
+			lines = append(lines, r.String())
+		}
+		return lines
+	}
+
+	It("returns an empty slice for independent values", func() {
+		a := newValue()
+		b := dyad.Clone(
+			a,
+			dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields),
+			dyad.WithChannelStrategy(dyad.IgnoreChannels),
+		)
+
+		Expect(dyad.SharedReferences(a, b)).To(BeEmpty())
+	})
+
+	It("reports each pair of paths that refer to the same memory", func() {
+		a := newValue()
+		b := a
+
+		Expect(render(dyad.SharedReferences(a, b))).To(ConsistOf(
+			"dyad_test.Value.Channel and dyad_test.Value.Channel refer to the same memory",
+			"dyad_test.Value.Map and dyad_test.Value.Map refer to the same memory",
+			"dyad_test.Value.Ptr and dyad_test.Value.Ptr refer to the same memory",
+			"dyad_test.Value.Slice and dyad_test.Value.Slice refer to the same memory",
+			"dyad_test.Value.private and dyad_test.Value.private refer to the same memory",
+		))
+	})
+
+	It("reports slices with overlapping backing arrays", func() {
+		a := newValue()
+		b := a.Slice[2:]
+
+		Expect(render(dyad.SharedReferences(a, b))).To(Equal([]string{
+			"dyad_test.Value.Slice and []int refer to the same memory",
+		}))
+	})
+
+	It("reports pointers into slice backing arrays", func() {
+		a := newValue()
+		b := &a.Slice[1]
+
+		Expect(render(dyad.SharedReferences(a, b))).To(Equal([]string{
+			"dyad_test.Value.Slice and *int refer to the same memory",
+		}))
+	})
+
+	It("reports shared values nested within different types", func() {
+		type Other struct {
+			Nested struct {
+				Items map[string]int
+			}
+		}
+
+		a := newValue()
+		var b Other
+		b.Nested.Items = a.Map
+
+		Expect(render(dyad.SharedReferences(a, b))).To(Equal([]string{
+			"dyad_test.Value.Map and dyad_test.Other.Nested.Items refer to the same memory",
+		}))
+	})
+
+	It("reports shared map keys", func() {
+		x := 1
+		a := map[*int]bool{&x: true}
+		b := map[*int]bool{&x: true}
+
+		shared := dyad.SharedReferences(a, b)
+
+		Expect(shared).To(HaveLen(1))
+		Expect(shared[0].A.String()).To(HavePrefix("map[*int]bool["))
+		Expect(shared[0].B.String()).To(HavePrefix("map[*int]bool["))
+	})
+
+	It("panics if the values cannot be traversed", func() {
+		a := newValue()
+
+		Expect(func() {
+			dyad.SharedReferences(
+				a,
+				a,
+				dyad.WithChannelStrategy(dyad.PanicOnChannel),
+			)
+		}).To(PanicWith(MatchError(
+			"dyad_test.Value.Channel: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		)))
+	})
+})
@@ -0,0 +1,172 @@
+package dyad
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// SizeOf returns an estimate of the number of bytes of memory occupied by v,
+// and every value reachable from it.
+//
+// It traverses values in the same way as Walk(). The estimate includes the
+// size of v itself, the capacity of slices, the content of strings, the values
+// referred to by pointers and interfaces, and an approximation of the memory
+// used by maps and channels. Memory that is reachable via multiple paths, such
+// as a value referred to by several pointers, is only counted once.
+//
+// Unexported fields and channels are included by default, as though the
+// CloneUnexportedFields and ShareChannels strategies were in use. Otherwise, it
+// panics under the same circumstances as Clone().
+func SizeOf[T any](v T, options ...Option) int {
+	options = append(
+		[]Option{
+			WithUnexportedFieldStrategy(CloneUnexportedFields),
+			WithChannelStrategy(ShareChannels),
+		},
+		options...,
+	)
+
+	root := reflect.ValueOf(&v).Elem()
+	s := &sizer{
+		size: int(root.Type().Size()),
+		seen: map[reference]struct{}{},
+	}
+
+	ctx := newCloneContext(options).WithRoot(root.Type())
+
+	if err := s.Walk(ctx, root); err != nil {
+		panic(err)
+	}
+
+	return s.size
+}
+
+// Approximations of the memory used by the internal representations of maps
+// and channels.
+const (
+	mapHeaderSize     = 48
+	mapLoadFactor     = 7.0 / 8.0
+	channelHeaderSize = 96
+)
+
+// sizer computes the memory occupied by a value.
+type sizer struct {
+	size int
+
+	// seen is the set of references that have already been counted.
+	seen map[reference]struct{}
+
+	// err is any error that occurred while walking map keys.
+	err error
+}
+
+func (s *sizer) Walk(ctx cloneContext, v reflect.Value) error {
+	w := &walker{
+		inProgress: map[reference]struct{}{},
+	}
+
+	w.visit = func(p Path, v reflect.Value, k reflect.Kind) WalkAction {
+		switch k {
+		case reflect.Ptr:
+			return s.count(v, int(v.Type().Elem().Size()))
+		case reflect.Slice:
+			return s.count(v, v.Cap()*int(v.Type().Elem().Size()))
+		case reflect.Map:
+			return s.countMap(ctx, p, v)
+		case reflect.Chan:
+			return s.count(v, channelHeaderSize+v.Cap()*int(v.Type().Elem().Size()))
+		case reflect.String:
+			return s.countString(v)
+		case reflect.Interface:
+			return s.countInterface(v)
+		default:
+			return ContinueWalk
+		}
+	}
+
+	if err := w.Walk(ctx, v); err != nil {
+		return err
+	}
+
+	return s.err
+}
+
+// count adds n bytes to the size for the data referred to by v, which must be
+// a pointer, slice, map or channel, unless that data has already been counted.
+func (s *sizer) count(v reflect.Value, n int) WalkAction {
+	if v.IsNil() {
+		return ContinueWalk
+	}
+
+	ref := referenceTo(v)
+
+	if _, ok := s.seen[ref]; ok {
+		return SkipChildren
+	}
+
+	s.seen[ref] = struct{}{}
+	s.size += n
+
+	return ContinueWalk
+}
+
+func (s *sizer) countMap(ctx cloneContext, p Path, v reflect.Value) WalkAction {
+	mapType := v.Type()
+	entrySize := int(mapType.Key().Size() + mapType.Elem().Size() + 1)
+
+	action := s.count(
+		v,
+		mapHeaderSize+int(float64(v.Len()*entrySize)/mapLoadFactor),
+	)
+
+	if action != ContinueWalk {
+		return action
+	}
+
+	// Walk() does not visit map keys, so we walk them separately to count any
+	// data they refer to.
+	ctx.path = p
+	for _, k := range sortedMapKeys(v) {
+		if err := s.Walk(ctx.WithKey(k), k); err != nil {
+			s.err = err
+			return StopWalk
+		}
+	}
+
+	return ContinueWalk
+}
+
+func (s *sizer) countString(v reflect.Value) WalkAction {
+	if v.Len() == 0 {
+		return ContinueWalk
+	}
+
+	str := v.String()
+	ref := reference{
+		uintptr(unsafe.Pointer(unsafe.StringData(str))),
+		v.Type(),
+	}
+
+	if _, ok := s.seen[ref]; !ok {
+		s.seen[ref] = struct{}{}
+		s.size += len(str)
+	}
+
+	return ContinueWalk
+}
+
+func (s *sizer) countInterface(v reflect.Value) WalkAction {
+	if v.IsNil() {
+		return ContinueWalk
+	}
+
+	// Values that are not pointer-shaped are stored within an interface by
+	// allocating memory for a copy of the value.
+	switch v.Elem().Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+	default:
+		s.size += int(v.Elem().Type().Size())
+	}
+
+	return ContinueWalk
+}
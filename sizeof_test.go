@@ -0,0 +1,97 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func SizeOf()", func() {
+	It("returns the size of a value that does not refer to any other memory", func() {
+		Expect(dyad.SizeOf(int64(123))).To(Equal(8))
+		Expect(dyad.SizeOf([4]int32{})).To(Equal(16))
+	})
+
+	It("includes the content of strings", func() {
+		Expect(dyad.SizeOf("<value>")).To(Equal(16 + 7))
+	})
+
+	It("includes the values referred to by pointers", func() {
+		n := int64(123)
+		Expect(dyad.SizeOf(&n)).To(Equal(8 + 8))
+	})
+
+	It("includes the capacity of slices", func() {
+		s := make([]int64, 1, 4)
+		Expect(dyad.SizeOf(s)).To(Equal(24 + 4*8))
+	})
+
+	It("includes the values within interfaces", func() {
+		var v any = int64(123)
+		Expect(dyad.SizeOf(v)).To(Equal(16 + 8))
+	})
+
+	It("includes the keys and elements of maps", func() {
+		small := dyad.SizeOf(map[string]string{})
+		large := dyad.SizeOf(map[string]string{"<key>": "<value>"})
+
+		Expect(large - small).To(BeNumerically(">", 5+7))
+	})
+
+	It("only counts memory that is reachable via multiple paths once", func() {
+		type Value struct {
+			A, B *int64
+		}
+
+		n := int64(123)
+		Expect(dyad.SizeOf(Value{&n, &n})).To(Equal(16 + 8))
+	})
+
+	It("supports cyclic values", func() {
+		type Node struct {
+			Next *Node
+		}
+
+		n := &Node{}
+		n.Next = n
+
+		Expect(dyad.SizeOf(n)).To(Equal(8 + 8))
+	})
+
+	It("includes unexported fields by default", func() {
+		type Value struct {
+			name string
+		}
+
+		Expect(dyad.SizeOf(Value{"<name>"})).To(Equal(16 + 6))
+	})
+
+	It("excludes unexported fields when using the IgnoreUnexportedFields strategy", func() {
+		type Value struct {
+			name string
+		}
+
+		Expect(dyad.SizeOf(
+			Value{"<name>"},
+			dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields),
+		)).To(Equal(16))
+	})
+
+	It("panics if the value cannot be cloned", func() {
+		Expect(func() {
+			dyad.SizeOf(
+				make(chan int),
+				dyad.WithChannelStrategy(dyad.PanicOnChannel),
+			)
+		}).To(PanicWith(MatchError(
+			"chan int: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		)))
+	})
+
+	It("includes channel buffers by default", func() {
+		small := dyad.SizeOf(make(chan int64))
+		large := dyad.SizeOf(make(chan int64, 10))
+
+		Expect(large - small).To(Equal(80))
+	})
+})
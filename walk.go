@@ -0,0 +1,249 @@
+package dyad
+
+import "reflect"
+
+// Walk calls visit for each value within v, including v itself.
+//
+// It traverses values in the same way as Clone(), visiting each value before
+// the values nested within it. Unexported fields and channels are visited
+// according to the same strategies used by Clone(). Values that Clone() would
+// ignore are not visited, and it returns an error under the same circumstances
+// that Clone() would panic.
+//
+// Pointers are visited before the values they point to, both of which have the
+// same path. Map elements are visited in order of their keys, but the keys
+// themselves are not visited, although they still cause an error if they
+// contain values that Clone() would panic on. time.Time values are visited,
+// but the values within them are not.
+//
+// Pointers, slices and maps that refer back to a value that is already being
+// walked are visited, but the values nested within them are not, such that
+// cyclic values can be walked safely.
+func Walk[T any](v T, visit Visitor, options ...Option) error {
+	w := &walker{
+		visit:      visit,
+		inProgress: map[reference]struct{}{},
+	}
+
+	root := reflect.ValueOf(&v).Elem()
+
+	return w.Walk(
+		newCloneContext(options).WithRoot(root.Type()),
+		root,
+	)
+}
+
+// A Visitor is a function that is called by Walk() for each value it visits.
+//
+// p is the path to the value, v is the value itself and k is its kind. Values
+// within unexported fields may be used as though they were exported.
+type Visitor func(p Path, v reflect.Value, k reflect.Kind) WalkAction
+
+// WalkAction is an enumeration of the actions that a Visitor can request of
+// Walk() after visiting a value.
+type WalkAction int
+
+const (
+	// ContinueWalk causes Walk() to visit the values nested within the
+	// visited value.
+	ContinueWalk WalkAction = iota
+
+	// SkipChildren causes Walk() to skip the values nested within the visited
+	// value.
+	SkipChildren
+
+	// StopWalk causes Walk() to return immediately, without visiting any more
+	// values.
+	StopWalk
+)
+
+// reference identifies the data referred to by a pointer, slice or map.
+type reference struct {
+	p uintptr
+	t reflect.Type
+}
+
+// referenceTo returns the reference that identifies the data referred to by
+// v, which must be a pointer, slice or map.
+func referenceTo(v reflect.Value) reference {
+	return reference{v.Pointer(), v.Type()}
+}
+
+// walker visits each value within some root value.
+type walker struct {
+	visit Visitor
+
+	// inProgress is the set of references that are currently being walked,
+	// used to avoid infinite recursion when walking cyclic values.
+	inProgress map[reference]struct{}
+
+	// stopped is true if the visitor has requested that the walk stop.
+	stopped bool
+}
+
+func (w *walker) Walk(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	if v.Kind() == reflect.Chan {
+		switch ctx.options.channelStrategy {
+		case ShareChannels:
+		case IgnoreChannels:
+			return nil
+		default:
+			return channelError(ctx)
+		}
+	}
+
+	switch w.visit(ctx.path, v, v.Kind()) {
+	case SkipChildren:
+		return nil
+	case StopWalk:
+		w.stopped = true
+		return nil
+	}
+
+	if v.Type() == timeType {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return w.walkInterface(ctx, v)
+	case reflect.Ptr:
+		return w.walkReference(ctx, v, func() error {
+			return w.Walk(ctx, v.Elem())
+		})
+	case reflect.Slice:
+		return w.walkReference(ctx, v, func() error {
+			return w.walkElements(ctx, v)
+		})
+	case reflect.Array:
+		return w.walkElements(ctx, v)
+	case reflect.Map:
+		return w.walkReference(ctx, v, func() error {
+			return w.walkMap(ctx, v)
+		})
+	case reflect.Struct:
+		return w.walkStruct(ctx, v)
+	default:
+		return nil
+	}
+}
+
+// walkReference calls fn to walk the values referred to by v, which must be a
+// pointer, slice or map, unless v is nil or those values are already being
+// walked.
+func (w *walker) walkReference(
+	ctx cloneContext,
+	v reflect.Value,
+	fn func() error,
+) error {
+	if v.IsNil() {
+		return nil
+	}
+
+	ref := referenceTo(v)
+
+	if _, ok := w.inProgress[ref]; ok {
+		return nil
+	}
+
+	w.inProgress[ref] = struct{}{}
+	defer delete(w.inProgress, ref)
+
+	return fn()
+}
+
+func (w *walker) walkInterface(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	if v.IsNil() {
+		return nil
+	}
+
+	elem := v.Elem()
+
+	return w.Walk(
+		ctx.WithType(elem.Type()),
+		elem,
+	)
+}
+
+func (w *walker) walkElements(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	for i := 0; i < v.Len() && !w.stopped; i++ {
+		if err := w.Walk(
+			ctx.WithIndex(i),
+			v.Index(i),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *walker) walkMap(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	// Keys are not visited, but they are walked without a visitor so that any
+	// values within them that cannot be cloned still produce an error.
+	keys := &walker{
+		visit: func(Path, reflect.Value, reflect.Kind) WalkAction {
+			return ContinueWalk
+		},
+		inProgress: w.inProgress,
+	}
+
+	for _, key := range sortedMapKeys(v) {
+		if w.stopped {
+			return nil
+		}
+
+		ctx := ctx.WithKey(key)
+
+		if err := keys.Walk(ctx, key); err != nil {
+			return err
+		}
+
+		if err := w.Walk(ctx, v.MapIndex(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *walker) walkStruct(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	size := v.NumField()
+	structType := v.Type()
+
+	for i := 0; i < size && !w.stopped; i++ {
+		field := structType.Field(i)
+
+		value, ok, err := fieldValue(ctx, v, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if err := w.Walk(
+			ctx.WithField(field.Name),
+			value,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package dyad_test
+
+import (
+	"reflect"
+
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Walk()", func() {
+	type Value struct {
+		Name      string
+		Ptr       *int
+		Slice     []string
+		Map       map[string]bool
+		Interface any
+	}
+
+	It("visits each value", func() {
+		n := 123
+		src := Value{
+			Name:      "<name>",
+			Ptr:       &n,
+			Slice:     []string{"<a>", "<b>"},
+			Map:       map[string]bool{"y": true, "x": false},
+			Interface: 1.5,
+		}
+
+		var visited []string
+		err := dyad.Walk(
+			src,
+			func(p dyad.Path, v reflect.Value, k reflect.Kind) dyad.WalkAction {
+				visited = append(visited, p.String()+" "+k.String())
+				return dyad.ContinueWalk
+			},
+		)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(visited).To(Equal([]string{
+			"dyad_test.Value struct",
+			"dyad_test.Value.Name string",
+			"dyad_test.Value.Ptr ptr",
+			"dyad_test.Value.Ptr int",
+			"dyad_test.Value.Slice slice",
+			"dyad_test.Value.Slice[0] string",
+			"dyad_test.Value.Slice[1] string",
+			`dyad_test.Value.Map map`,
+			`dyad_test.Value.Map["x"] bool`,
+			`dyad_test.Value.Map["y"] bool`,
+			"dyad_test.Value.Interface interface",
+			"dyad_test.Value.Interface(float64) float64",
+		}))
+	})
+
+	It("allows the visitor to skip the values within a value", func() {
+		src := Value{Slice: []string{"<a>"}}
+
+		var visited []string
+		err := dyad.Walk(
+			src,
+			func(p dyad.Path, v reflect.Value, k reflect.Kind) dyad.WalkAction {
+				visited = append(visited, p.String())
+
+				if k == reflect.Slice {
+					return dyad.SkipChildren
+				}
+
+				return dyad.ContinueWalk
+			},
+		)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(visited).To(ContainElement("dyad_test.Value.Slice"))
+		Expect(visited).ToNot(ContainElement("dyad_test.Value.Slice[0]"))
+	})
+
+	It("allows the visitor to stop the walk", func() {
+		var visited []string
+		err := dyad.Walk(
+			Value{},
+			func(p dyad.Path, v reflect.Value, k reflect.Kind) dyad.WalkAction {
+				visited = append(visited, p.String())
+
+				if k == reflect.String {
+					return dyad.StopWalk
+				}
+
+				return dyad.ContinueWalk
+			},
+		)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(visited).To(Equal([]string{
+			"dyad_test.Value",
+			"dyad_test.Value.Name",
+		}))
+	})
+
+	It("supports cyclic values", func() {
+		type Node struct {
+			Next *Node
+		}
+
+		src := &Node{}
+		src.Next = src
+
+		count := 0
+		err := dyad.Walk(
+			src,
+			func(dyad.Path, reflect.Value, reflect.Kind) dyad.WalkAction {
+				count++
+				return dyad.ContinueWalk
+			},
+		)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(count).To(Equal(3)) // *Node, Node, Node.Next
+	})
+
+	It("visits unexported fields according to the unexported field strategy", func() {
+		type Value struct {
+			unexported string
+		}
+
+		var visited []any
+		visit := func(p dyad.Path, v reflect.Value, k reflect.Kind) dyad.WalkAction {
+			visited = append(visited, v.Interface())
+			return dyad.ContinueWalk
+		}
+
+		err := dyad.Walk(Value{"<value>"}, visit)
+		Expect(err).To(MatchError(
+			"dyad_test.Value: struct cannot be cloned due to unexported field (dyad_test.Value.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+		))
+
+		visited = nil
+		err = dyad.Walk(Value{"<value>"}, visit, dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(visited).To(Equal([]any{Value{"<value>"}, "<value>"}))
+
+		visited = nil
+		err = dyad.Walk(Value{"<value>"}, visit, dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(visited).To(Equal([]any{Value{"<value>"}}))
+	})
+
+	It("returns an error if a channel is within an array, as Clone() does", func() {
+		err := dyad.Walk(
+			[1]chan int{make(chan int)},
+			func(dyad.Path, reflect.Value, reflect.Kind) dyad.WalkAction {
+				return dyad.ContinueWalk
+			},
+		)
+
+		Expect(err).To(MatchError(
+			"[1]chan int[0]: channels cannot be cloned, try the dyad.WithChannelStrategy() option",
+		))
+	})
+
+	It("returns an error if a map key cannot be cloned", func() {
+		type Key struct {
+			unexported int
+		}
+
+		err := dyad.Walk(
+			map[Key]int{{1}: 1},
+			func(dyad.Path, reflect.Value, reflect.Kind) dyad.WalkAction {
+				return dyad.ContinueWalk
+			},
+		)
+
+		Expect(err).To(MatchError(
+			"map[dyad_test.Key]int[dyad_test.Key{unexported:1}]: struct cannot be cloned due to unexported field (dyad_test.Key.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+		))
+	})
+})
@@ -0,0 +1,215 @@
+package dyad
+
+import "reflect"
+
+// Wipe overwrites the value pointed to by v, and every value reachable from
+// it, with zero-values.
+//
+// It traverses values in the same way as Clone(). The elements of slices are
+// overwritten up to their capacity, maps are cleared, and pointers, slices,
+// maps and interfaces are set to nil after the values they refer to have been
+// wiped. This makes it suitable for scrubbing secrets, such as credentials,
+// from memory once they are no longer needed.
+//
+// The contents of strings are immutable and therefore cannot be overwritten
+// safely, so strings are replaced with the empty string. The memory they
+// occupied is reclaimed by the garbage collector as usual.
+//
+// Likewise, the value stored within an interface cannot be overwritten in
+// place, as the Go runtime may store it in memory that is shared or read-only.
+// Interfaces are set to nil after wiping any data that the stored value refers
+// to, such as the value pointed to by a pointer, but a value that is stored
+// directly, such as a struct or array, keeps its contents until it is reclaimed
+// by the garbage collector. Store secrets within interfaces via a pointer to
+// ensure that they are overwritten.
+//
+// Unexported fields and channels are wiped according to the same strategies
+// used by Clone(), and it panics under the same circumstances. Channels are
+// never closed.
+func Wipe[T any](v *T, options ...Option) {
+	w := &wiper{
+		visited: map[reference]struct{}{},
+	}
+
+	root := reflect.ValueOf(v).Elem()
+
+	if err := w.Wipe(
+		newCloneContext(options).WithRoot(root.Type()),
+		root,
+	); err != nil {
+		panic(err)
+	}
+}
+
+// wiper overwrites values with zero-values.
+type wiper struct {
+	// visited is the set of references that have already been wiped, used to
+	// avoid wiping the same data twice and infinite recursion when wiping
+	// cyclic values.
+	visited map[reference]struct{}
+}
+
+func (w *wiper) Wipe(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	if v.Type() == timeType {
+		v.SetZero()
+		return nil
+	}
+
+	var err error
+
+	switch v.Kind() {
+	case reflect.Interface:
+		err = w.wipeInterface(ctx, v)
+	case reflect.Ptr:
+		err = w.wipeReference(v, func() error {
+			return w.Wipe(ctx, v.Elem())
+		})
+	case reflect.Slice:
+		err = w.wipeReference(v, func() error {
+			return w.wipeElements(ctx, v.Slice(0, v.Cap()))
+		})
+	case reflect.Array:
+		return w.wipeElements(ctx, v)
+	case reflect.Map:
+		err = w.wipeReference(v, func() error {
+			return w.wipeMap(ctx, v)
+		})
+	case reflect.Struct:
+		return w.wipeStruct(ctx, v)
+	case reflect.Chan:
+		if ctx.options.channelStrategy == PanicOnChannel {
+			return channelError(ctx)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v.SetZero()
+
+	return nil
+}
+
+// wipeReference calls fn to wipe the values referred to by v, which must be a
+// pointer, slice or map, unless v is nil or those values have already been
+// wiped.
+func (w *wiper) wipeReference(
+	v reflect.Value,
+	fn func() error,
+) error {
+	if v.IsNil() {
+		return nil
+	}
+
+	ref := referenceTo(v)
+
+	if _, ok := w.visited[ref]; ok {
+		return nil
+	}
+
+	w.visited[ref] = struct{}{}
+
+	return fn()
+}
+
+func (w *wiper) wipeInterface(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	if v.IsNil() {
+		return nil
+	}
+
+	// The value within an interface is not addressable, so we wipe a copy of
+	// it instead. This wipes any data that the value refers to, but not the
+	// original value itself, which is left intact until the interface is set
+	// to nil and the value is reclaimed by the garbage collector.
+	elem := v.Elem()
+	value := reflect.New(elem.Type()).Elem()
+	value.Set(elem)
+
+	return w.Wipe(
+		ctx.WithType(elem.Type()),
+		value,
+	)
+}
+
+func (w *wiper) wipeElements(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := w.Wipe(
+			ctx.WithIndex(i),
+			v.Index(i),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *wiper) wipeMap(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	mapType := v.Type()
+
+	for _, key := range v.MapKeys() {
+		ctx := ctx.WithKey(key)
+
+		// Map keys and elements are not addressable, so we wipe copies of them
+		// instead. This still wipes any data that they refer to.
+		elem := reflect.New(mapType.Elem()).Elem()
+		elem.Set(v.MapIndex(key))
+
+		if err := w.Wipe(ctx, elem); err != nil {
+			return err
+		}
+
+		k := reflect.New(mapType.Key()).Elem()
+		k.Set(key)
+
+		if err := w.Wipe(ctx, k); err != nil {
+			return err
+		}
+	}
+
+	v.Clear()
+
+	return nil
+}
+
+func (w *wiper) wipeStruct(
+	ctx cloneContext,
+	v reflect.Value,
+) error {
+	size := v.NumField()
+	structType := v.Type()
+
+	for i := 0; i < size; i++ {
+		field := structType.Field(i)
+
+		value, ok, err := fieldValue(ctx, v, i)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if err := w.Wipe(
+			ctx.WithField(field.Name),
+			value,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
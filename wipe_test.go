@@ -0,0 +1,85 @@
+package dyad_test
+
+import (
+	"github.com/dogmatiq/dyad"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Wipe()", func() {
+	type Credentials struct {
+		Username string
+		Password []byte
+		Metadata map[string]*int
+		Extra    any
+	}
+
+	It("zeroes the value and everything reachable from it", func() {
+		n := 123
+		password := []byte("<password>")
+		extra := []byte("<extra>")
+
+		v := &Credentials{
+			Username: "<user>",
+			Password: password,
+			Metadata: map[string]*int{"<key>": &n},
+			Extra:    &extra,
+		}
+		metadata := v.Metadata
+
+		dyad.Wipe(&v)
+
+		Expect(v).To(BeNil())
+		Expect(password).To(Equal(make([]byte, len(password))))
+		Expect(extra).To(BeNil())
+		Expect(metadata).To(BeEmpty())
+		Expect(n).To(BeZero())
+	})
+
+	It("wipes slice elements beyond the length of the slice", func() {
+		data := []byte("<secret>")
+		v := data[:2]
+
+		dyad.Wipe(&v)
+
+		Expect(v).To(BeNil())
+		Expect(data).To(Equal(make([]byte, len(data))))
+	})
+
+	It("supports cyclic values", func() {
+		type Node struct {
+			Value int
+			Next  *Node
+		}
+
+		node := &Node{Value: 123}
+		node.Next = node
+
+		v := node
+		dyad.Wipe(&v)
+
+		Expect(v).To(BeNil())
+		Expect(*node).To(Equal(Node{}))
+	})
+
+	It("wipes unexported fields according to the unexported field strategy", func() {
+		type Value struct {
+			unexported []byte
+		}
+
+		data := []byte("<secret>")
+		v := Value{data}
+
+		Expect(func() {
+			dyad.Wipe(&v)
+		}).To(PanicWith(MatchError(
+			"dyad_test.Value: struct cannot be cloned due to unexported field (dyad_test.Value.unexported), try the dyad.WithUnexportedFieldStrategy() option",
+		)))
+
+		dyad.Wipe(&v, dyad.WithUnexportedFieldStrategy(dyad.IgnoreUnexportedFields))
+		Expect(data).To(Equal([]byte("<secret>")))
+
+		dyad.Wipe(&v, dyad.WithUnexportedFieldStrategy(dyad.CloneUnexportedFields))
+		Expect(data).To(Equal(make([]byte, len(data))))
+	})
+})